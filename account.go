@@ -0,0 +1,253 @@
+package subkey
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+)
+
+// AccountIDLength is the length of a Substrate AccountId32.
+const AccountIDLength = 32
+
+// defaultNetwork is the SS58 format used when marshalling AccountIDs.
+var defaultNetwork atomic.Uint32
+
+func init() {
+	defaultNetwork.Store(42)
+}
+
+// SetDefaultNetwork sets the SS58 format used by AccountID.String and AccountID.MarshalText.
+// Formats above 16383 and the reserved formats 46 and 47 are rejected.
+func SetDefaultNetwork(format uint16) error {
+	if err := checkSS58Format(format); err != nil {
+		return err
+	}
+
+	defaultNetwork.Store(uint32(format))
+	return nil
+}
+
+// DefaultNetwork returns the SS58 format used by AccountID.String and AccountID.MarshalText.
+// It is 42 (substrate) unless changed with SetDefaultNetwork.
+func DefaultNetwork() uint16 {
+	return uint16(defaultNetwork.Load())
+}
+
+// AccountID is a 32 byte Substrate account identifier.
+// It marshals to an SS58 address in the default network
+// and parses SS58 addresses or hex encoded account IDs.
+type AccountID [AccountIDLength]byte
+
+// NewAccountID returns the AccountID for the bytes.
+func NewAccountID(b []byte) (AccountID, error) {
+	var id AccountID
+	if len(b) != AccountIDLength {
+		return id, &LengthError{Err: ErrInvalidAddress, Got: len(b), Want: []int{AccountIDLength}}
+	}
+
+	copy(id[:], b)
+	return id, nil
+}
+
+// AccountIDOf returns the AccountID of the public key.
+func AccountIDOf(pub PublicKey) (AccountID, error) {
+	return NewAccountID(pub.AccountID())
+}
+
+// ParseAccountID parses an SS58 address or a hex encoded account ID.
+func ParseAccountID(s string) (AccountID, error) {
+	a, err := ParseAddress(s)
+	return a.AccountID, err
+}
+
+// Bytes returns a copy of the account ID bytes.
+func (id AccountID) Bytes() []byte {
+	b := make([]byte, AccountIDLength)
+	copy(b, id[:])
+	return b
+}
+
+// Hex returns the 0x prefixed hex encoding of the account ID.
+func (id AccountID) Hex() string {
+	return EncodeHex(id[:])
+}
+
+// Equal reports whether both account IDs are the same.
+func (id AccountID) Equal(other AccountID) bool {
+	return id == other
+}
+
+// Compare returns -1, 0 or 1 if the account ID is less than, equal to or greater than other.
+func (id AccountID) Compare(other AccountID) int {
+	return bytes.Compare(id[:], other[:])
+}
+
+// IsZero reports whether all bytes of the account ID are zero.
+func (id AccountID) IsZero() bool {
+	return id == AccountID{}
+}
+
+// SS58Address returns the SS58 address of the account ID on the network,
+// or the hex encoded account ID if the network is not a valid SS58 format.
+func (id AccountID) SS58Address(network uint16) string {
+	s, err := SS58EncodeStrict(id[:], network)
+	if err != nil {
+		return id.Hex()
+	}
+
+	return s
+}
+
+// Address returns the account ID bound to the network.
+func (id AccountID) Address(network uint16) Address {
+	return Address{AccountID: id, Network: network}
+}
+
+// String returns the SS58 address in the default network.
+func (id AccountID) String() string {
+	return id.SS58Address(DefaultNetwork())
+}
+
+// MarshalText encodes the account ID as an SS58 address in the default network.
+func (id AccountID) MarshalText() ([]byte, error) {
+	s, err := SS58EncodeStrict(id[:], DefaultNetwork())
+	return []byte(s), err
+}
+
+// UnmarshalText parses an SS58 address or hex encoded account ID.
+func (id *AccountID) UnmarshalText(text []byte) error {
+	parsed, err := ParseAccountID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
+
+// Value implements driver.Valuer and stores the account ID as raw bytes.
+func (id AccountID) Value() (driver.Value, error) {
+	return id.Bytes(), nil
+}
+
+// Scan implements sql.Scanner and accepts raw bytes or an SS58/hex string.
+func (id *AccountID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		if len(v) == AccountIDLength {
+			copy(id[:], v)
+			return nil
+		}
+
+		return id.UnmarshalText(v)
+	case string:
+		return id.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("%w: cannot scan %T into AccountID", ErrInvalidAddress, src)
+	}
+}
+
+// Address is an AccountID bound to an SS58 network.
+// It marshals to the SS58 address in its own network.
+type Address struct {
+	AccountID AccountID
+	Network   uint16
+}
+
+// NewAddress returns the address of the public key on the network.
+func NewAddress(pub PublicKey, network uint16) (Address, error) {
+	id, err := AccountIDOf(pub)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return id.Address(network), nil
+}
+
+// ParseAddress parses an SS58 address or a hex encoded account ID.
+// Hex encoded account IDs are bound to the default network.
+func ParseAddress(s string) (Address, error) {
+	if b, ok := DecodeHex(s); ok && len(b) == AccountIDLength {
+		id, err := NewAccountID(b)
+		return id.Address(DefaultNetwork()), err
+	}
+
+	format, b, err := SS58Decode(s)
+	if err != nil {
+		return Address{}, err
+	}
+
+	id, err := NewAccountID(b)
+	return id.Address(format), err
+}
+
+// Equal reports whether both addresses have the same account ID and network.
+func (a Address) Equal(other Address) bool {
+	return a == other
+}
+
+// Compare orders addresses by account ID and then by network.
+func (a Address) Compare(other Address) int {
+	if c := a.AccountID.Compare(other.AccountID); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.Network < other.Network:
+		return -1
+	case a.Network > other.Network:
+		return 1
+	}
+
+	return 0
+}
+
+// In returns the same account on another network.
+func (a Address) In(network uint16) Address {
+	return a.AccountID.Address(network)
+}
+
+// String returns the SS58 address, or the hex encoded account ID if the network is not a valid SS58 format.
+func (a Address) String() string {
+	return a.AccountID.SS58Address(a.Network)
+}
+
+// MarshalText encodes the address as SS58.
+func (a Address) MarshalText() ([]byte, error) {
+	s, err := SS58EncodeStrict(a.AccountID[:], a.Network)
+	return []byte(s), err
+}
+
+// UnmarshalText parses an SS58 address or hex encoded account ID.
+func (a *Address) UnmarshalText(text []byte) error {
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+	return nil
+}
+
+// Value implements driver.Valuer and stores the address as its SS58 string.
+func (a Address) Value() (driver.Value, error) {
+	b, err := a.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner and accepts an SS58/hex string.
+func (a *Address) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return a.UnmarshalText(v)
+	case string:
+		return a.UnmarshalText([]byte(v))
+	default:
+		return fmt.Errorf("%w: cannot scan %T into Address", ErrInvalidAddress, src)
+	}
+}
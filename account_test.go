@@ -0,0 +1,107 @@
+package subkey_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+const (
+	aliceSubstrate = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	aliceHeiko     = "hJKzPoi3MQnSLvbShxeDmzbtHncrMXe5zwS3Wa36P6kXeNpcv"
+	aliceHex       = "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+)
+
+func TestAccountID(t *testing.T) {
+	kr, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Alice")
+	assert.NoError(t, err)
+	alice, err := subkey.AccountIDOf(kr)
+	assert.NoError(t, err)
+	assert.Equal(t, aliceHex, alice.Hex())
+	assert.Equal(t, aliceSubstrate, alice.String())
+
+	for _, s := range []string{aliceSubstrate, aliceHeiko, aliceHex} {
+		id, err := subkey.ParseAccountID(s)
+		assert.NoError(t, err)
+		assert.True(t, alice.Equal(id))
+	}
+
+	bob, err := subkey.ParseAccountID("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, alice.Compare(bob))
+	assert.Equal(t, -1, bob.Compare(alice))
+	assert.False(t, alice.IsZero())
+
+	b, err := json.Marshal(map[string]subkey.AccountID{"who": alice})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"who":"`+aliceSubstrate+`"}`, string(b))
+
+	var got map[string]subkey.AccountID
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, alice, got["who"])
+
+	v, err := alice.Value()
+	assert.NoError(t, err)
+	var scanned subkey.AccountID
+	assert.NoError(t, scanned.Scan(v))
+	assert.Equal(t, alice, scanned)
+	assert.NoError(t, scanned.Scan(aliceHeiko))
+	assert.Equal(t, alice, scanned)
+	assert.Error(t, scanned.Scan(42))
+
+	_, err = subkey.ParseAccountID("0x1234")
+	assert.Error(t, err)
+}
+
+func TestAddress(t *testing.T) {
+	addr, err := subkey.ParseAddress(aliceHeiko)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(110), addr.Network)
+	assert.Equal(t, aliceHeiko, addr.String())
+	assert.Equal(t, aliceSubstrate, addr.In(42).String())
+	assert.Equal(t, 1, addr.Compare(addr.In(42)))
+
+	b, err := json.Marshal(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+aliceHeiko+`"`, string(b))
+
+	var got subkey.Address
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.True(t, addr.Equal(got))
+
+	v, err := addr.Value()
+	assert.NoError(t, err)
+	var scanned subkey.Address
+	assert.NoError(t, scanned.Scan(v))
+	assert.Equal(t, addr, scanned)
+
+	addr, err = subkey.ParseAddress(aliceHex)
+	assert.NoError(t, err)
+	assert.Equal(t, subkey.DefaultNetwork(), addr.Network)
+}
+
+func TestInvalidNetwork(t *testing.T) {
+	alice, err := subkey.ParseAccountID(aliceSubstrate)
+	assert.NoError(t, err)
+
+	for _, network := range []uint16{46, 47, 16_384, 20_000} {
+		assert.Error(t, subkey.SetDefaultNetwork(network), network)
+		assert.Equal(t, uint16(42), subkey.DefaultNetwork())
+
+		// addresses of user input must stay printable
+		assert.Equal(t, aliceHex, alice.SS58Address(network))
+		assert.Equal(t, aliceHex, alice.Address(network).String())
+		_, err := alice.Address(network).MarshalText()
+		assert.Error(t, err)
+	}
+
+	assert.ErrorIs(t, subkey.SetDefaultNetwork(46), subkey.ErrReservedFormat)
+	assert.ErrorIs(t, subkey.SetDefaultNetwork(16_384), subkey.ErrInvalidFormat)
+
+	assert.NoError(t, subkey.SetDefaultNetwork(110))
+	t.Cleanup(func() { assert.NoError(t, subkey.SetDefaultNetwork(42)) })
+	assert.Equal(t, aliceHeiko, alice.String())
+}
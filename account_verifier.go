@@ -0,0 +1,7 @@
+package subkey
+
+// AccountVerifier is implemented by schemes that can verify a signature against an account ID
+// without knowing the public key. See multi.VerifyByAddress to verify against any account.
+type AccountVerifier interface {
+	VerifyAccount(accountID, msg, signature []byte) bool
+}
@@ -0,0 +1,68 @@
+package subkey
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchItem is a signature to be verified as part of a batch.
+type BatchItem struct {
+	PublicKey PublicKey
+	Message   []byte
+	Signature []byte
+}
+
+// BatchVerifier is implemented by schemes with native batch verification.
+type BatchVerifier interface {
+	// VerifyBatch returns the indices of the items with invalid signatures in ascending order.
+	VerifyBatch(items []BatchItem) (failed []int)
+}
+
+// VerifyBatch verifies the signatures of all items and returns the indices of the failed ones.
+// A nil result means every signature is valid.
+// Schemes implementing BatchVerifier, such as sr25519 and ed25519, verify natively
+// and accept the same signatures as their Verify.
+// Others, such as ecdsa, verify each item in parallel with their single signature rules.
+func VerifyBatch(scheme Scheme, items []BatchItem) (failed []int) {
+	if bv, ok := scheme.(BatchVerifier); ok {
+		return bv.VerifyBatch(items)
+	}
+
+	return VerifyEach(items)
+}
+
+// VerifyEach verifies the items one by one in parallel and returns the indices of the failed ones.
+func VerifyEach(items []BatchItem) (failed []int) {
+	results := make([]bool, len(items))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				item := items[i]
+				results[i] = item.PublicKey != nil && item.PublicKey.Verify(item.Message, item.Signature)
+			}
+		}()
+	}
+
+	for i := range items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for i, ok := range results {
+		if !ok {
+			failed = append(failed, i)
+		}
+	}
+
+	return failed
+}
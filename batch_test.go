@@ -0,0 +1,86 @@
+package subkey_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func batchItems(t *testing.T, scheme subkey.Scheme, n int) []subkey.BatchItem {
+	items := make([]subkey.BatchItem, n)
+	for i := range items {
+		kp, err := scheme.Generate()
+		assert.NoError(t, err)
+		msg := []byte(fmt.Sprintf("extrinsic %d", i))
+		sig, err := kp.Sign(msg)
+		assert.NoError(t, err)
+		pub, err := scheme.FromPublicKey(kp.Public())
+		assert.NoError(t, err)
+		items[i] = subkey.BatchItem{PublicKey: pub, Message: msg, Signature: sig}
+	}
+
+	return items
+}
+
+func TestVerifyBatch(t *testing.T) {
+	// native reports whether the scheme verifies batches itself
+	tests := map[subkey.Scheme]bool{
+		sr25519.Scheme{}: true,
+		ed25519.Scheme{}: true,
+		ecdsa.Scheme{}:   false,
+	}
+
+	for scheme, native := range tests {
+		_, ok := scheme.(subkey.BatchVerifier)
+		assert.Equal(t, native, ok, scheme.String())
+
+		items := batchItems(t, scheme, 16)
+		assert.Nil(t, subkey.VerifyBatch(scheme, items))
+		assert.Nil(t, subkey.VerifyBatch(scheme, nil))
+
+		items[3].Message = []byte("tampered")
+		items[7].Signature = items[7].Signature[:10]
+		items[11].PublicKey = items[12].PublicKey
+		items[13].PublicKey = nil
+		assert.Equal(t, []int{3, 7, 11, 13}, subkey.VerifyBatch(scheme, items))
+		assert.Equal(t, []int{3, 7, 11, 13}, subkey.VerifyEach(items))
+	}
+}
+
+func TestVerifyBatchEd25519ZIP215(t *testing.T) {
+	// a small order key and signature of the ZIP-215 vectors, valid for ed25519-zebra and rejected by RFC 8032
+	public, _ := subkey.DecodeHex("0x0100000000000000000000000000000000000000000000000000000000000000")
+	pub, err := ed25519.Scheme{}.FromPublicKey(public)
+	assert.NoError(t, err)
+	sig, _ := subkey.DecodeHex("0x" +
+		"0100000000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000000")
+	msg := []byte("Zcash")
+	assert.True(t, pub.Verify(msg, sig))
+
+	items := append(batchItems(t, ed25519.Scheme{}, 3), subkey.BatchItem{PublicKey: pub, Message: msg, Signature: sig})
+	assert.Nil(t, subkey.VerifyBatch(ed25519.Scheme{}, items))
+
+	// the fallback after a failed batch uses the same rules
+	items[1].Message = []byte("tampered")
+	assert.Equal(t, []int{1}, subkey.VerifyBatch(ed25519.Scheme{}, items))
+}
+
+func TestVerifyBatchSr25519Fallback(t *testing.T) {
+	// the fallback verifies the sr25519 keys of the batch even when the items carry other key types
+	items := batchItems(t, sr25519.Scheme{}, 4)
+	for i := range items {
+		pub, err := ed25519.Scheme{}.FromPublicKey(items[i].PublicKey.Public())
+		assert.NoError(t, err)
+		items[i].PublicKey = pub
+	}
+
+	assert.Nil(t, subkey.VerifyBatch(sr25519.Scheme{}, items))
+	items[2].Message = []byte("tampered")
+	assert.Equal(t, []int{2}, subkey.VerifyBatch(sr25519.Scheme{}, items))
+}
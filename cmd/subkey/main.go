@@ -5,20 +5,28 @@ import (
 	"fmt"
 
 	"github.com/vedhavyas/go-subkey/v2"
-	"github.com/vedhavyas/go-subkey/v2/sr25519"
+	_ "github.com/vedhavyas/go-subkey/v2/ecdsa"
+	_ "github.com/vedhavyas/go-subkey/v2/ed25519"
+	_ "github.com/vedhavyas/go-subkey/v2/sr25519"
 )
 
 func main() {
 	s := flag.String("secret", "", "Secret key in Hex")
 	m := flag.String("msg", "", "Message to be signed in Hex")
+	n := flag.String("scheme", "sr25519", "Cryptography scheme: sr25519, ed25519, ecdsa or ethereum")
 	flag.Parse()
 
+	scheme, err := subkey.SchemeByName(*n)
+	if err != nil {
+		panic(err)
+	}
+
 	msg, ok := subkey.DecodeHex(*m)
 	if !ok {
 		panic(fmt.Errorf("invalid hex"))
 	}
 
-	kr, err := subkey.DeriveKeyPair(sr25519.Scheme{}, *s)
+	kr, err := subkey.DeriveKeyPair(scheme, *s)
 	if err != nil {
 		panic(err)
 	}
@@ -2,8 +2,6 @@ package subkey
 
 import (
 	"encoding/binary"
-	"errors"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -17,30 +15,12 @@ const (
 	junctionIDLen = 32
 )
 
-var (
-	re = regexp.MustCompile(`^(?P<phrase>[\d\w ]+)?(?P<path>(//?[^/]+)*)(///(?P<password>.*))?$`)
-
-	reJunction = regexp.MustCompile(`/(/?[^/]+)`)
-)
-
+// DeriveJunction is a chain code and hardness used to derive a child key.
 type DeriveJunction struct {
 	ChainCode [32]byte
 	IsHard    bool
 }
 
-func deriveJunctions(codes []string) (djs []DeriveJunction, err error) {
-	for _, code := range codes {
-		dj, err := parseDeriveJunction(code)
-		if err != nil {
-			return nil, err
-		}
-
-		djs = append(djs, dj)
-	}
-
-	return djs, nil
-}
-
 func parseDeriveJunction(code string) (DeriveJunction, error) {
 	var jd DeriveJunction
 	if strings.HasPrefix(code, "/") {
@@ -70,25 +50,3 @@ func parseDeriveJunction(code string) (DeriveJunction, error) {
 	copy(jd.ChainCode[:len(bc)], bc)
 	return jd, nil
 }
-
-func derivePath(path string) (parts []string) {
-	res := reJunction.FindAllStringSubmatch(path, -1)
-	for _, p := range res {
-		parts = append(parts, p[1])
-	}
-	return parts
-}
-
-func splitURI(suri string) (phrase string, pathMap string, password string, err error) {
-	res := re.FindStringSubmatch(suri)
-	if res == nil {
-		return phrase, pathMap, password, errors.New("invalid URI format")
-	}
-
-	phrase = res[1]
-	if phrase == "" {
-		phrase = DevPhrase
-	}
-
-	return phrase, res[2], res[5], nil
-}
@@ -7,7 +7,7 @@ import (
 )
 
 //nolint:funlen
-func TestSplitURI(t *testing.T) {
+func TestParseSecretURIParts(t *testing.T) {
 	tests := []struct {
 		suri, phrase, path, password string
 		err                          bool
@@ -92,15 +92,15 @@ func TestSplitURI(t *testing.T) {
 
 	for _, c := range tests {
 		t.Run(c.suri, func(t *testing.T) {
-			phrase, path, password, err := splitURI(c.suri)
+			u, err := ParseSecretURI(c.suri)
 			if err != nil {
 				assert.True(t, c.err)
 				return
 			}
 
-			assert.Equal(t, c.phrase, phrase)
-			assert.Equal(t, c.path, path)
-			assert.Equal(t, c.password, password)
+			assert.Equal(t, c.phrase, u.PhraseOrDev())
+			assert.Equal(t, c.path, u.Path())
+			assert.Equal(t, c.password, u.Password)
 		})
 	}
 }
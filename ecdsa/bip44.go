@@ -0,0 +1,75 @@
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/go-bip39"
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// hardenedIndex is added to the index of hardened BIP32 children.
+const hardenedIndex = 1 << 31
+
+// EthereumDerivationPath is the BIP44 path of the first Ethereum account, m/44'/60'/0'/0/0.
+// EthereumScheme derives the keys of phrases at this path like MetaMask and Moonbeam do.
+var EthereumDerivationPath = []uint32{44 + hardenedIndex, 60 + hardenedIndex, hardenedIndex, 0, 0}
+
+// bip44Seed returns the secret key at the BIP32 path of the BIP39 phrase and password.
+func bip44Seed(phrase, pwd string, path []uint32) ([]byte, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(phrase, pwd)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidPhrase, err)
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+	for _, index := range path {
+		if key, chainCode, err = bip32Child(key, chainCode, index); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// bip32Child derives the private child key at the index as defined by BIP32.
+func bip32Child(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= hardenedIndex {
+		data = append([]byte{0}, key...)
+	} else {
+		secret, err := secp256k1.ToECDSA(key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", subkey.ErrInvalidSeed, err)
+		}
+
+		data = secp256k1.CompressPubkey(&secret.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	// the chance of an invalid child is below 2^-127, BIP32 skips to the next index
+	n := secp256k1.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, nil, fmt.Errorf("%w: invalid child key at index %d", subkey.ErrInvalidSeed, index)
+	}
+
+	child := tweak.Add(tweak, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, fmt.Errorf("%w: invalid child key at index %d", subkey.ErrInvalidSeed, index)
+	}
+
+	return child.FillBytes(make([]byte, seedLength)), sum[32:], nil
+}
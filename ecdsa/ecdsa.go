@@ -3,7 +3,7 @@ package ecdsa
 import (
 	"bytes"
 	"crypto/ecdsa"
-	"errors"
+	"fmt"
 
 	"github.com/ChainSafe/go-schnorrkel"
 	secp256k1 "github.com/ethereum/go-ethereum/crypto"
@@ -12,23 +12,36 @@ import (
 	"golang.org/x/crypto/blake2b"
 )
 
+const (
+	seedLength = 32
+
+	publicKeyLength = 33
+
+	signatureLength = 64
+
+	recoverableSignatureLength = 65
+)
+
 type keyRing struct {
 	secret *ecdsa.PrivateKey
 	pub    *ecdsa.PublicKey
 }
 
 func (kr keyRing) Sign(msg []byte) (signature []byte, err error) {
-	digest := blake2b.Sum256(msg)
-	return secp256k1.Sign(digest[:], kr.secret)
+	digest := Blake2b256(msg)
+	return signPrehashed(kr, digest[:])
 }
 
 func (kr keyRing) Verify(msg []byte, signature []byte) bool {
-	digest := blake2b.Sum256(msg)
-	signature = signature[:64]
-	return secp256k1.VerifySignature(kr.Public(), digest[:], signature)
+	digest := Blake2b256(msg)
+	return verifySignature(kr, digest[:], signature)
 }
 
 func (kr keyRing) Seed() []byte {
+	if !kr.hasSecret() {
+		return nil
+	}
+
 	return secp256k1.FromECDSA(kr.secret)
 }
 
@@ -42,7 +55,42 @@ func (kr keyRing) AccountID() []byte {
 }
 
 func (kr keyRing) SS58Address(network uint16) string {
-	return subkey.SS58Encode(kr.AccountID(), network)
+	address, _ := subkey.SS58EncodeStrict(kr.AccountID(), network)
+	return address
+}
+
+// toKeyRing returns the keyRing behind a key pair produced by this scheme.
+func toKeyRing(pair subkey.KeyPair) (keyRing, error) {
+	var kr keyRing
+	switch v := pair.(type) {
+	case keyRing:
+		kr = v
+	case *keyRing:
+		if v == nil {
+			return kr, fmt.Errorf("%w: nil", subkey.ErrUnsupportedKeyPair)
+		}
+		kr = *v
+	case ethKeyRing:
+		kr = v.keyRing
+	case *ethKeyRing:
+		if v == nil {
+			return kr, fmt.Errorf("%w: nil", subkey.ErrUnsupportedKeyPair)
+		}
+		kr = v.keyRing
+	default:
+		return kr, fmt.Errorf("%w: %T", subkey.ErrUnsupportedKeyPair, pair)
+	}
+
+	if !kr.hasSecret() {
+		return kr, subkey.ErrMissingSecret
+	}
+
+	return kr, nil
+}
+
+func init() {
+	subkey.RegisterScheme(Scheme{})
+	subkey.RegisterScheme(EthereumScheme{})
 }
 
 type Scheme struct{}
@@ -64,7 +112,15 @@ func (s Scheme) Generate() (subkey.KeyPair, error) {
 }
 
 func (s Scheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
-	secret := secp256k1.ToECDSAUnsafe(seed)
+	if len(seed) != seedLength {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidSeedLength, Got: len(seed), Want: []int{seedLength}}
+	}
+
+	secret, err := secp256k1.ToECDSA(seed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidSeed, err)
+	}
+
 	pub := secret.Public().(*ecdsa.PublicKey)
 	return keyRing{
 		secret: secret,
@@ -75,18 +131,22 @@ func (s Scheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
 func (s Scheme) FromPhrase(phrase, pwd string) (subkey.KeyPair, error) {
 	seed, err := schnorrkel.SeedFromMnemonic(phrase, pwd)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidPhrase, err)
 	}
 
 	return s.FromSeed(seed[:32])
 }
 
 func (s Scheme) Derive(pair subkey.KeyPair, djs []subkey.DeriveJunction) (subkey.KeyPair, error) {
-	acc := secp256k1.FromECDSA(pair.(keyRing).secret)
-	var err error
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := secp256k1.FromECDSA(kr.secret)
 	for _, dj := range djs {
 		if !dj.IsHard {
-			return nil, errors.New("soft derivation is not supported")
+			return nil, subkey.ErrSoftJunctionNotSupported
 		}
 
 		acc, err = deriveKeyHard(acc, dj.ChainCode)
@@ -120,9 +180,13 @@ func deriveKeyHard(secret []byte, cc [32]byte) ([]byte, error) {
 }
 
 func (s Scheme) FromPublicKey(bytes []byte) (subkey.PublicKey, error) {
+	if len(bytes) != publicKeyLength {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidPublicKey, Got: len(bytes), Want: []int{publicKeyLength}}
+	}
+
 	key, err := secp256k1.DecompressPubkey(bytes)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidPublicKey, err)
 	}
 
 	return &keyRing{pub: key}, nil
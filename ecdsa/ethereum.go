@@ -0,0 +1,107 @@
+package ecdsa
+
+import (
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// H160Length is the length of an Ethereum style AccountId20.
+const H160Length = 20
+
+// EthereumKeyPair is an ecdsa key pair with a Frontier style AccountId20.
+// Messages are signed over their keccak-256 digest like Frontier's EthereumSignature.
+// SS58Address encodes the Substrate account of the public key, as for Scheme,
+// since SS58 has no 20 byte accounts. EthereumAddress is the address Frontier chains use.
+type EthereumKeyPair interface {
+	subkey.KeyPair
+
+	// H160 returns the 20 byte account derived from the keccak-256 hash of the public key.
+	H160() [H160Length]byte
+
+	// EthereumAddress returns the EIP-55 checksummed hex encoding of the H160 account.
+	EthereumAddress() string
+}
+
+type ethKeyRing struct {
+	keyRing
+}
+
+func (kr ethKeyRing) Sign(msg []byte) (signature []byte, err error) {
+	digest := Keccak256(msg)
+	return signPrehashed(kr.keyRing, digest[:])
+}
+
+func (kr ethKeyRing) Verify(msg []byte, signature []byte) bool {
+	digest := Keccak256(msg)
+	return verifySignature(kr.keyRing, digest[:], signature)
+}
+
+func (kr ethKeyRing) H160() [H160Length]byte {
+	return secp256k1.PubkeyToAddress(*kr.pub)
+}
+
+func (kr ethKeyRing) EthereumAddress() string {
+	return secp256k1.PubkeyToAddress(*kr.pub).Hex()
+}
+
+func (kr ethKeyRing) AccountID() []byte {
+	h160 := kr.H160()
+	return h160[:]
+}
+
+// EthereumScheme is the ecdsa scheme with Frontier style AccountId20 accounts.
+// Phrases are turned into keys at EthereumDerivationPath, seeds and junctions work like Scheme.
+type EthereumScheme struct{}
+
+func (s EthereumScheme) String() string {
+	return "Ethereum"
+}
+
+func (s EthereumScheme) Generate() (subkey.KeyPair, error) {
+	return toEthereum(Scheme{}.Generate())
+}
+
+func (s EthereumScheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
+	return toEthereum(Scheme{}.FromSeed(seed))
+}
+
+func (s EthereumScheme) FromPhrase(phrase, pwd string) (subkey.KeyPair, error) {
+	seed, err := bip44Seed(phrase, pwd, EthereumDerivationPath)
+	if err != nil {
+		return nil, err
+	}
+	defer subkey.Zero(seed)
+
+	return s.FromSeed(seed)
+}
+
+func (s EthereumScheme) Derive(pair subkey.KeyPair, djs []subkey.DeriveJunction) (subkey.KeyPair, error) {
+	return toEthereum(Scheme{}.Derive(pair, djs))
+}
+
+func (s EthereumScheme) FromPublicKey(bytes []byte) (subkey.PublicKey, error) {
+	pub, err := Scheme{}.FromPublicKey(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethKeyRing{keyRing: *pub.(*keyRing)}, nil
+}
+
+// Ethereum returns the Frontier style view of an ecdsa key pair.
+func Ethereum(pair subkey.KeyPair) (EthereumKeyPair, error) {
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethKeyRing{keyRing: kr}, nil
+}
+
+func toEthereum(pair subkey.KeyPair, err error) (subkey.KeyPair, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return Ethereum(pair)
+}
@@ -0,0 +1,93 @@
+package ecdsa
+
+import (
+	"testing"
+
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+func TestEthereumScheme(t *testing.T) {
+	// Alith, the Moonbeam development account
+	kp, err := EthereumScheme{}.FromSeed(fromHex(t, "0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133"))
+	assert.NoError(t, err)
+
+	eth := kp.(EthereumKeyPair)
+	assert.Equal(t, "0xf24FF3a9CF04c71Dbc94D0b566f7A27B94566cac", eth.EthereumAddress())
+	assert.Equal(t, fromHex(t, "0xf24FF3a9CF04c71Dbc94D0b566f7A27B94566cac"), kp.AccountID())
+	// SS58 addresses are the ones of the Substrate account
+	substrateAccount, err := Scheme{}.FromSeed(kp.Seed())
+	assert.NoError(t, err)
+	assert.Equal(t, substrateAccount.SS58Address(42), kp.SS58Address(42))
+
+	msg := []byte("frontier")
+	sig, err := kp.Sign(msg)
+	assert.NoError(t, err)
+	assert.True(t, kp.Verify(msg, sig))
+
+	pub, err := secp256k1.SigToPub(secp256k1.Keccak256(msg), sig)
+	assert.NoError(t, err)
+	assert.Equal(t, eth.H160(), [H160Length]byte(secp256k1.PubkeyToAddress(*pub)))
+
+	substrate, err := Scheme{}.FromSeed(kp.Seed())
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Public(), substrate.Public())
+	assert.False(t, substrate.Verify(msg, sig))
+
+	converted, err := Ethereum(substrate)
+	assert.NoError(t, err)
+	assert.Equal(t, eth.H160(), converted.H160())
+
+	pk, err := EthereumScheme{}.FromPublicKey(kp.Public())
+	assert.NoError(t, err)
+	assert.True(t, pk.Verify(msg, sig))
+	assert.Equal(t, kp.AccountID(), pk.AccountID())
+
+	// junctions are applied to the key of the BIP44 path
+	derived, err := subkey.DeriveKeyPair(EthereumScheme{}, "//Alice")
+	assert.NoError(t, err)
+	u, err := subkey.ParseSecretURI("//Alice")
+	assert.NoError(t, err)
+	djs, err := u.DeriveJunctions()
+	assert.NoError(t, err)
+	want, err := Scheme{}.Derive(kp, djs)
+	assert.NoError(t, err)
+	assert.Equal(t, want.Public(), derived.Public())
+	assert.Len(t, derived.AccountID(), H160Length)
+}
+
+func TestEthereumFromPhrase(t *testing.T) {
+	// the development accounts of Moonbeam, at m/44'/60'/0'/0/<index> of the dev phrase
+	tests := []struct {
+		index         uint32
+		seed, address string
+	}{
+		{
+			index:   0,
+			seed:    "0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133",
+			address: "0xf24FF3a9CF04c71Dbc94D0b566f7A27B94566cac",
+		},
+		{
+			index:   1,
+			seed:    "0x8075991ce870b93a8870eca0c0f91913d12f47948ca0fd25b49c6fa7cdbeee8b",
+			address: "0x3Cd0A705a2DC65e5b1E1205896BaA2be8A07c6e0",
+		},
+	}
+
+	for _, c := range tests {
+		path := append([]uint32{}, EthereumDerivationPath...)
+		path[len(path)-1] = c.index
+		seed, err := bip44Seed(subkey.DevPhrase, "", path)
+		assert.NoError(t, err)
+		assert.Equal(t, fromHex(t, c.seed), seed)
+	}
+
+	kp, err := EthereumScheme{}.FromPhrase(subkey.DevPhrase, "")
+	assert.NoError(t, err)
+	assert.Equal(t, fromHex(t, tests[0].seed), kp.Seed())
+	assert.Equal(t, tests[0].address, kp.(EthereumKeyPair).EthereumAddress())
+
+	_, err = EthereumScheme{}.FromPhrase("bottom drive obey lake", "")
+	assert.ErrorIs(t, err, subkey.ErrInvalidPhrase)
+}
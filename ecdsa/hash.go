@@ -0,0 +1,93 @@
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+// HashFunc hashes a message to the 32 byte digest that is signed.
+type HashFunc func(msg []byte) [32]byte
+
+// Blake2b256 is the blake2_256 hash used by Substrate's ecdsa Pair.
+func Blake2b256(msg []byte) [32]byte {
+	return blake2b.Sum256(msg)
+}
+
+// Keccak256 is the keccak-256 hash used by Ethereum, Frontier and BEEFY.
+func Keccak256(msg []byte) [32]byte {
+	return secp256k1.Keccak256Hash(msg)
+}
+
+// SHA256 is the sha-256 hash.
+func SHA256(msg []byte) [32]byte {
+	return sha256.Sum256(msg)
+}
+
+// SignWithHash signs the digest of msg computed with hash.
+func SignWithHash(pair subkey.KeyPair, msg []byte, hash HashFunc) ([]byte, error) {
+	digest := hash(msg)
+	return SignPrehashed(pair, digest[:])
+}
+
+// VerifyWithHash verifies the signature over the digest of msg computed with hash, like VerifyPrehashed.
+func VerifyWithHash(pub subkey.PublicKey, msg, signature []byte, hash HashFunc) bool {
+	digest := hash(msg)
+	return VerifyPrehashed(pub, digest[:], signature)
+}
+
+// SignPrehashed signs the 32 byte digest directly and returns a 65 byte recoverable signature.
+// It mirrors Substrate's ecdsa::Pair::sign_prehashed.
+func SignPrehashed(pair subkey.KeyPair, digest []byte) ([]byte, error) {
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	return signPrehashed(kr, digest)
+}
+
+// VerifyPrehashed verifies the 65 byte recoverable signature over the 32 byte digest.
+// It mirrors Substrate's ecdsa::Pair::verify_prehashed: the public key is recovered from the signature
+// and compared, so high-S signatures are accepted and 64 byte signatures are not.
+// This differs from the Verify method of the key pairs, see verifySignature.
+// Use VerifyPrehashedStrict for other policies.
+func VerifyPrehashed(pub subkey.PublicKey, digest, signature []byte) bool {
+	recovered, err := RecoverPrehashed(digest, signature)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(recovered.Public(), pub.Public())
+}
+
+func signPrehashed(kr keyRing, digest []byte) ([]byte, error) {
+	if !kr.hasSecret() {
+		return nil, subkey.ErrMissingSecret
+	}
+
+	if len(digest) != secp256k1.DigestLength {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidDigestLength, Got: len(digest), Want: []int{secp256k1.DigestLength}}
+	}
+
+	sig, err := secp256k1.Sign(digest, kr.secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign digest: %w", err)
+	}
+
+	return sig, nil
+}
+
+// verifySignature verifies the [R || S] part of a 64 or 65 byte signature over the digest.
+// It is the Verify of the key pairs: the recovery byte is ignored and high-S signatures are rejected.
+func verifySignature(kr keyRing, digest, signature []byte) bool {
+	if len(signature) != signatureLength && len(signature) != recoverableSignatureLength {
+		return false
+	}
+
+	return secp256k1.VerifySignature(kr.Public(), digest, signature[:signatureLength])
+}
@@ -0,0 +1,52 @@
+package ecdsa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+func TestSignWithHash(t *testing.T) {
+	kp, err := Scheme{}.Generate()
+	assert.NoError(t, err)
+	msg := []byte("beefy")
+
+	for _, hash := range []HashFunc{Blake2b256, Keccak256, SHA256} {
+		sig, err := SignWithHash(kp, msg, hash)
+		assert.NoError(t, err)
+		assert.True(t, VerifyWithHash(kp, msg, sig, hash))
+
+		digest := hash(msg)
+		assert.True(t, VerifyPrehashed(kp, digest[:], sig))
+		assert.NoError(t, VerifyStrict(kp, msg, sig, VerifyPolicy{RequireRecoveryID: true, LowS: true, Hash: hash}))
+
+		pub, err := RecoverPrehashed(digest[:], sig)
+		assert.NoError(t, err)
+		assert.Equal(t, kp.Public(), pub.Public())
+	}
+
+	sig, err := SignWithHash(kp, msg, Blake2b256)
+	assert.NoError(t, err)
+	assert.True(t, kp.Verify(msg, sig))
+	assert.False(t, VerifyWithHash(kp, msg, sig, Keccak256))
+
+	digest := Keccak256(msg)
+	sig, err = SignPrehashed(kp, digest[:])
+	assert.NoError(t, err)
+	eth, err := Ethereum(kp)
+	assert.NoError(t, err)
+	assert.True(t, eth.Verify(msg, sig))
+
+	// the key is recovered from the signature, so the recovery byte is required and checked
+	assert.False(t, VerifyPrehashed(eth, digest[:], sig[:64]))
+	flipped := append([]byte{}, sig...)
+	flipped[64] ^= 1
+	assert.False(t, VerifyPrehashed(eth, digest[:], flipped))
+	flipped[64] = 4
+	assert.False(t, VerifyPrehashed(eth, digest[:], flipped))
+
+	_, err = SignPrehashed(kp, digest[:31])
+	assert.ErrorIs(t, err, subkey.ErrInvalidDigestLength)
+	assert.False(t, VerifyPrehashed(kp, digest[:31], sig))
+}
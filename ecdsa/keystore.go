@@ -0,0 +1,276 @@
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// StandardScryptN and StandardScryptP are the scrypt parameters geth uses by default.
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// LightScryptN and LightScryptP are the scrypt parameters of geth's --lightkdf.
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	keystoreVersion = 3
+	keystoreCipher  = "aes-128-ctr"
+	keystoreDKLen   = 32
+	keystoreScryptR = 8
+
+	kdfScrypt = "scrypt"
+	kdfPBKDF2 = "pbkdf2"
+	prfSHA256 = "hmac-sha256"
+
+	// geth writes scrypt with the standard parameters, and the Web3 Secret Storage test
+	// vectors swap r and p. Files may not exceed either or the n·r·p work of those, nor
+	// 2^24 pbkdf2 iterations, so opening a hostile file stays about as cheap as a geth one.
+	maxScryptN     = StandardScryptN
+	maxScryptR     = keystoreScryptR
+	maxScryptP     = keystoreScryptR
+	maxScryptWork  = StandardScryptN * keystoreScryptR * StandardScryptP
+	maxPBKDF2Count = 1 << 24
+)
+
+type keystoreJSON struct {
+	Address string         `json:"address,omitempty"`
+	Crypto  keystoreCrypto `json:"crypto"`
+	ID      string         `json:"id"`
+	Version int            `json:"version"`
+}
+
+type keystoreCrypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams keystoreCipherParams   `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type keystoreCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// EncryptKeystore encrypts the ecdsa key pair into an Ethereum V3 keystore file
+// with the standard geth scrypt parameters.
+func EncryptKeystore(pair subkey.KeyPair, password string) ([]byte, error) {
+	return EncryptKeystoreWithParams(pair, password, StandardScryptN, StandardScryptP)
+}
+
+// EncryptKeystoreWithParams encrypts the ecdsa key pair into an Ethereum V3 keystore file
+// with the scrypt parameters N and P, like geth's keystore.EncryptKey.
+func EncryptKeystoreWithParams(pair subkey.KeyPair, password string, scryptN, scryptP int) ([]byte, error) {
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	id := make([]byte, 16)
+	for _, b := range [][]byte{salt, iv, id} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+	}
+
+	derived, err := scrypt.Key([]byte(password), salt, scryptN, keystoreScryptR, scryptP, keystoreDKLen)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := aesCTR(derived[:16], iv, kr.Seed())
+	if err != nil {
+		return nil, err
+	}
+
+	// random UUID, version 4
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	address := secp256k1.PubkeyToAddress(*kr.pub)
+	return json.Marshal(keystoreJSON{
+		Address: hex.EncodeToString(address[:]),
+		Crypto: keystoreCrypto{
+			Cipher:       keystoreCipher,
+			CipherText:   hex.EncodeToString(ciphertext),
+			CipherParams: keystoreCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          kdfScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     keystoreScryptR,
+				"p":     scryptP,
+				"dklen": keystoreDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(keystoreMAC(derived, ciphertext)),
+		},
+		ID:      fmt.Sprintf("%x-%x-%x-%x-%x", id[:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Version: keystoreVersion,
+	})
+}
+
+// DecryptKeystore decrypts the Ethereum V3 keystore file with the password.
+// The key pair signs like Scheme, use Ethereum to get the keccak-256 signing EthereumKeyPair.
+// ErrInvalidPassword is returned if the MAC does not match.
+func DecryptKeystore(data []byte, password string) (subkey.KeyPair, error) {
+	var ks keystoreJSON
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	if ks.Version != keystoreVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", subkey.ErrInvalidKeystore, ks.Version)
+	}
+
+	if ks.Crypto.Cipher != keystoreCipher {
+		return nil, fmt.Errorf("%w: unsupported cipher %s", subkey.ErrInvalidKeystore, ks.Crypto.Cipher)
+	}
+
+	ciphertext, err := decodeKeystoreHex(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := decodeKeystoreHex(ks.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := decodeKeystoreHex(ks.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := keystoreKey(ks.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(keystoreMAC(derived, ciphertext), mac) {
+		return nil, subkey.ErrInvalidPassword
+	}
+
+	seed, err := aesCTR(derived[:16], iv, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	if len(seed) < seedLength {
+		// old geth versions stripped the leading zero bytes of the key
+		seed = append(make([]byte, seedLength-len(seed)), seed...)
+	}
+	defer subkey.Zero(seed)
+
+	pair, err := Scheme{}.FromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if ks.Address != "" {
+		kr, err := toKeyRing(pair)
+		if err != nil {
+			return nil, err
+		}
+
+		address := secp256k1.PubkeyToAddress(*kr.pub)
+		if !strings.EqualFold(strings.TrimPrefix(ks.Address, "0x"), hex.EncodeToString(address[:])) {
+			return nil, fmt.Errorf("%w: address does not match the key", subkey.ErrInvalidKeystore)
+		}
+	}
+
+	return pair, nil
+}
+
+// keystoreKey derives the 32 byte key of the keystore from the password.
+func keystoreKey(c keystoreCrypto, password string) ([]byte, error) {
+	salt, err := decodeKeystoreHex(fmt.Sprint(c.KDFParams["salt"]))
+	if err != nil {
+		return nil, err
+	}
+
+	if dklen := kdfParam(c.KDFParams, "dklen"); dklen != keystoreDKLen {
+		return nil, fmt.Errorf("%w: unsupported dklen %d", subkey.ErrInvalidKeystore, dklen)
+	}
+
+	switch c.KDF {
+	case kdfScrypt:
+		n, r, p := kdfParam(c.KDFParams, "n"), kdfParam(c.KDFParams, "r"), kdfParam(c.KDFParams, "p")
+		if n <= 1 || n&(n-1) != 0 || n > maxScryptN || r < 1 || r > maxScryptR || p < 1 || p > maxScryptP ||
+			n*r*p > maxScryptWork {
+			return nil, fmt.Errorf("%w: scrypt parameters out of range", subkey.ErrInvalidKeystore)
+		}
+
+		key, err := scrypt.Key([]byte(password), salt, n, r, p, keystoreDKLen)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+		}
+
+		return key, nil
+	case kdfPBKDF2:
+		if prf := c.KDFParams["prf"]; prf != prfSHA256 {
+			return nil, fmt.Errorf("%w: unsupported prf %v", subkey.ErrInvalidKeystore, prf)
+		}
+
+		count := kdfParam(c.KDFParams, "c")
+		if count <= 0 || count > maxPBKDF2Count {
+			return nil, fmt.Errorf("%w: pbkdf2 iterations out of range", subkey.ErrInvalidKeystore)
+		}
+
+		return pbkdf2.Key([]byte(password), salt, count, keystoreDKLen, sha256.New), nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported kdf %s", subkey.ErrInvalidKeystore, c.KDF)
+}
+
+// kdfParam returns the integer parameter, or 0 if it is missing or not a number.
+func kdfParam(params map[string]interface{}, name string) int {
+	f, ok := params[name].(float64)
+	if !ok {
+		return 0
+	}
+
+	return int(f)
+}
+
+// keystoreMAC is the keccak-256 of the second half of the derived key followed by the ciphertext.
+func keystoreMAC(derived, ciphertext []byte) []byte {
+	return secp256k1.Keccak256(derived[16:32], ciphertext)
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("%w: invalid iv length", subkey.ErrInvalidKeystore)
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func decodeKeystoreHex(s string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	return b, nil
+}
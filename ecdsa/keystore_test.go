@@ -0,0 +1,149 @@
+package ecdsa
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// test vectors of the Web3 Secret Storage Definition
+const (
+	pbkdf2Keystore = `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {
+				"c": 262144,
+				"dklen": 32,
+				"prf": "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+			},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+
+	scryptKeystore = `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {
+				"dklen": 32,
+				"n": 262144,
+				"p": 8,
+				"r": 1,
+				"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+			},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+
+	// old geth versions stripped the leading zero bytes of keys
+	shortKeystore = `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "e0c41130a323adc1446fc82f724bca2f"},
+			"ciphertext": "9517cd5bdbe69076f9bf5057248c6c050141e970efa36ce53692d5d59a3984",
+			"kdf": "scrypt",
+			"kdfparams": {
+				"dklen": 32,
+				"n": 2,
+				"r": 8,
+				"p": 1,
+				"salt": "711f816911c92d649fb4c84b047915679933555030b3552c1212609b38208c63"
+			},
+			"mac": "d5e116151c6aa71470e67a7d42c9620c75c4d23229847dcc127794f0732b0db5"
+		},
+		"id": "fecfc4ce-e956-48fd-953b-30f8b52ed66c",
+		"version": 3
+	}`
+)
+
+func TestDecryptKeystore(t *testing.T) {
+	seed := fromHex(t, "0x7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	for _, data := range []string{pbkdf2Keystore, scryptKeystore} {
+		kp, err := DecryptKeystore([]byte(data), "testpassword")
+		assert.NoError(t, err)
+		assert.Equal(t, seed, kp.Seed())
+
+		eth, err := Ethereum(kp)
+		assert.NoError(t, err)
+		assert.Equal(t, "0x008AeEda4D805471dF9b2A5B0f38A0C3bCBA786b", eth.EthereumAddress())
+
+		_, err = DecryptKeystore([]byte(data), "wrong")
+		assert.ErrorIs(t, err, subkey.ErrInvalidPassword)
+	}
+
+	kp, err := DecryptKeystore([]byte(shortKeystore), "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, fromHex(t, "0x00fa7b3db73dc7dfdf8c5fbdb796d741e4488628c41fc4febd9160a866ba0f35"), kp.Seed())
+}
+
+func TestEncryptKeystore(t *testing.T) {
+	kp, err := subkey.DeriveKeyPair(EthereumScheme{}, "//Alice")
+	assert.NoError(t, err)
+
+	data, err := EncryptKeystoreWithParams(kp, "password", LightScryptN, LightScryptP)
+	assert.NoError(t, err)
+
+	var ks keystoreJSON
+	assert.NoError(t, json.Unmarshal(data, &ks))
+	assert.Equal(t, 3, ks.Version)
+	assert.Equal(t, "scrypt", ks.Crypto.KDF)
+	assert.Len(t, ks.ID, 36)
+	assert.Equal(t, byte('4'), ks.ID[14])
+	eth := kp.(EthereumKeyPair)
+	assert.Equal(t, strings.ToLower(eth.EthereumAddress()[2:]), ks.Address)
+
+	got, err := DecryptKeystore(data, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Seed(), got.Seed())
+	assert.Equal(t, kp.Public(), got.Public())
+
+	// a different address is rejected
+	ks.Address = "008aeeda4d805471df9b2a5b0f38a0c3bcba786b"
+	data, err = json.Marshal(ks)
+	assert.NoError(t, err)
+	_, err = DecryptKeystore(data, "password")
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+
+	pub, err := Scheme{}.FromPublicKey(kp.Public())
+	assert.NoError(t, err)
+	_, err = EncryptKeystore(pub.(subkey.KeyPair), "password")
+	assert.ErrorIs(t, err, subkey.ErrMissingSecret)
+}
+
+func TestDecryptKeystoreErrors(t *testing.T) {
+	tests := []string{
+		`{`,
+		`{"version": 1}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-cbc"}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "bcrypt", "kdfparams": {"dklen": 32, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 1073741824, "r": 8, "p": 1, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 1024, "r": 8, "p": 0, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 1024, "r": 0, "p": 1, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 1, "r": 8, "p": 1, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 1000, "r": 8, "p": 1, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 1024, "r": 16, "p": 1, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 1024, "r": 8, "p": 16, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 262144, "r": 8, "p": 8, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "pbkdf2", "kdfparams": {"dklen": 32, "c": 1, "prf": "hmac-sha512", "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "kdf": "scrypt", "kdfparams": {"dklen": 16, "n": 1024, "r": 8, "p": 1, "salt": "00"}}}`,
+		`{"version": 3, "crypto": {"cipher": "aes-128-ctr", "ciphertext": "zz"}}`,
+	}
+
+	for _, data := range tests {
+		_, err := DecryptKeystore([]byte(data), "password")
+		assert.ErrorIs(t, err, subkey.ErrInvalidKeystore, data)
+	}
+}
@@ -0,0 +1,84 @@
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"fmt"
+
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Recover returns the public key that signed the blake2_256 digest of msg.
+// The signature must be the 65 byte recoverable signature produced by Sign.
+func Recover(msg, signature []byte) (subkey.PublicKey, error) {
+	digest := blake2b.Sum256(msg)
+	return RecoverPrehashed(digest[:], signature)
+}
+
+// RecoverPrehashed returns the public key that signed the 32 byte digest.
+func RecoverPrehashed(digest, signature []byte) (subkey.PublicKey, error) {
+	pub, err := recoverPublicKey(digest, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyRing{pub: pub}, nil
+}
+
+// RecoverEthereum returns the Frontier style public key that signed the keccak-256 digest of msg.
+func RecoverEthereum(msg, signature []byte) (EthereumKeyPair, error) {
+	pub, err := recoverPublicKey(secp256k1.Keccak256(msg), signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethKeyRing{keyRing: keyRing{pub: pub}}, nil
+}
+
+func recoverPublicKey(digest, signature []byte) (*ecdsa.PublicKey, error) {
+	if len(digest) != secp256k1.DigestLength {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidDigestLength, Got: len(digest), Want: []int{secp256k1.DigestLength}}
+	}
+
+	if len(signature) != recoverableSignatureLength {
+		return nil, &subkey.LengthError{
+			Err:  subkey.ErrInvalidSignatureLength,
+			Got:  len(signature),
+			Want: []int{recoverableSignatureLength},
+		}
+	}
+
+	// recovery ids 2 and 3 are valid but only occur for r values above the curve order
+	if signature[64] > 3 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRecoveryID, signature[64])
+	}
+
+	pub, err := secp256k1.SigToPub(digest, signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidSignature, err)
+	}
+
+	return pub, nil
+}
+
+// VerifyAccount recovers the signer of msg and checks that the blake2_256 hash of its public key is the account ID.
+func (s Scheme) VerifyAccount(accountID, msg, signature []byte) bool {
+	pub, err := Recover(msg, signature)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(pub.AccountID(), accountID) && pub.Verify(msg, signature)
+}
+
+// VerifyAccount recovers the signer of msg and checks that its H160 is the account ID.
+func (s EthereumScheme) VerifyAccount(accountID, msg, signature []byte) bool {
+	pub, err := RecoverEthereum(msg, signature)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(pub.AccountID(), accountID) && pub.Verify(msg, signature)
+}
@@ -0,0 +1,50 @@
+package ecdsa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestRecover(t *testing.T) {
+	addr := "5C7C2Z5sWbytvHpuLTvzKunnnRwQxft1jiqrLD5rhucQ5S9X"
+	msg := fromHex(t, "0xDEADBEEF")
+	sig := fromHex(t, "9f04ffd6c579e5460417c4b7a21441c39a0a0eb6a5c4a8cad288f538863950930f47c4014dbfc411f9486d432f55b875a0ff5c08ff15708120ae96b4a6e92b3800")
+
+	pub, err := Recover(msg, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, addr, pub.SS58Address(42))
+	assert.True(t, pub.Verify(msg, sig))
+
+	digest := blake2b.Sum256(msg)
+	pub, err = RecoverPrehashed(digest[:], sig)
+	assert.NoError(t, err)
+	assert.Equal(t, addr, pub.SS58Address(42))
+
+	_, err = Recover(msg, sig[:64])
+	assert.ErrorIs(t, err, subkey.ErrInvalidSignatureLength)
+
+	_, err = RecoverPrehashed(digest[:31], sig)
+	assert.ErrorIs(t, err, subkey.ErrInvalidDigestLength)
+
+	bad := append([]byte{}, sig...)
+	bad[64] = 9
+	_, err = Recover(msg, bad)
+	assert.ErrorIs(t, err, subkey.ErrInvalidSignature)
+}
+
+func TestRecoverEthereum(t *testing.T) {
+	kp, err := EthereumScheme{}.FromSeed(fromHex(t, "0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133"))
+	assert.NoError(t, err)
+
+	msg := []byte("claim")
+	sig, err := kp.Sign(msg)
+	assert.NoError(t, err)
+
+	pub, err := RecoverEthereum(msg, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xf24FF3a9CF04c71Dbc94D0b566f7A27B94566cac", pub.EthereumAddress())
+	assert.Equal(t, kp.Public(), pub.Public())
+}
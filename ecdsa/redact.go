@@ -0,0 +1,71 @@
+package ecdsa
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// hasSecret reports whether the key ring holds a secret that was not wiped.
+func (kr keyRing) hasSecret() bool {
+	return kr.secret != nil && kr.secret.D != nil && kr.secret.D.Sign() != 0
+}
+
+// Wipe zeroes the secret scalar.
+func (kr keyRing) Wipe() {
+	if kr.secret == nil || kr.secret.D == nil {
+		return
+	}
+
+	// SetInt64 keeps the backing array, so the words are cleared first
+	clear(kr.secret.D.Bits())
+	kr.secret.D.SetInt64(0)
+}
+
+// Close wipes the key ring.
+func (kr keyRing) Close() error {
+	kr.Wipe()
+	return nil
+}
+
+// String describes the key ring without its secret.
+func (kr keyRing) String() string {
+	return subkey.RedactedString(Scheme{}.String(), kr.Public(), kr.hasSecret())
+}
+
+// GoString describes the key ring without its secret.
+func (kr keyRing) GoString() string {
+	return kr.String()
+}
+
+// Format prints the key ring without its secret for every verb.
+func (kr keyRing) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, kr.String())
+}
+
+// LogValue logs the key ring without its secret.
+func (kr keyRing) LogValue() slog.Value {
+	return subkey.RedactedLogValue(Scheme{}.String(), kr.Public(), kr.hasSecret())
+}
+
+// String describes the key ring without its secret.
+func (kr ethKeyRing) String() string {
+	return subkey.RedactedString(EthereumScheme{}.String(), kr.Public(), kr.hasSecret())
+}
+
+// GoString describes the key ring without its secret.
+func (kr ethKeyRing) GoString() string {
+	return kr.String()
+}
+
+// Format prints the key ring without its secret for every verb.
+func (kr ethKeyRing) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, kr.String())
+}
+
+// LogValue logs the key ring without its secret.
+func (kr ethKeyRing) LogValue() slog.Value {
+	return subkey.RedactedLogValue(EthereumScheme{}.String(), kr.Public(), kr.hasSecret())
+}
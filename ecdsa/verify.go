@@ -0,0 +1,124 @@
+package ecdsa
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// The policy errors wrap subkey.ErrInvalidSignature.
+var (
+	// ErrHighS is returned when a signature has an S value in the upper half of the curve order.
+	ErrHighS = fmt.Errorf("%w: S value is not canonical", subkey.ErrInvalidSignature)
+	// ErrInvalidRecoveryID is returned when the recovery byte of a signature is out of range.
+	ErrInvalidRecoveryID = fmt.Errorf("%w: recovery id out of range", subkey.ErrInvalidSignature)
+	// ErrRecoveryMismatch is returned when a signature recovers to a different public key.
+	ErrRecoveryMismatch = fmt.Errorf("%w: recovers to a different public key", subkey.ErrInvalidSignature)
+
+	secp256k1N     = secp256k1.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// VerifyPolicy configures the checks done by VerifyStrict.
+type VerifyPolicy struct {
+	// RequireRecoveryID rejects 64 byte signatures without the recovery byte.
+	RequireRecoveryID bool
+	// LowS rejects signatures with S in the upper half of the curve order.
+	LowS bool
+	// AllowEthereumV accepts the Ethereum style recovery bytes 27 and 28.
+	AllowEthereumV bool
+	// Hash overrides the message hash used by VerifyStrict.
+	Hash HashFunc
+}
+
+// StrictPolicy requires 65 byte low-S signatures with a recovery byte of 0 or 1.
+var StrictPolicy = VerifyPolicy{
+	RequireRecoveryID: true,
+	LowS:              true,
+}
+
+// VerifyStrict verifies the signature of msg against the ecdsa public key following the policy.
+// Unless the policy sets Hash, messages are hashed like Sign does for the key:
+// blake2_256, or keccak-256 for EthereumKeyPair.
+// A nil error means the signature is valid.
+func VerifyStrict(pub subkey.PublicKey, msg, signature []byte, policy VerifyPolicy) error {
+	hash := policy.Hash
+	if hash == nil {
+		hash = Blake2b256
+		if _, ok := pub.(EthereumKeyPair); ok {
+			hash = Keccak256
+		}
+	}
+
+	digest := hash(msg)
+	return VerifyPrehashedStrict(pub, digest[:], signature, policy)
+}
+
+// VerifyPrehashedStrict verifies the signature of the 32 byte digest against the ecdsa public key following the policy.
+func VerifyPrehashedStrict(pub subkey.PublicKey, digest, signature []byte, policy VerifyPolicy) error {
+	if len(digest) != secp256k1.DigestLength {
+		return &subkey.LengthError{Err: subkey.ErrInvalidDigestLength, Got: len(digest), Want: []int{secp256k1.DigestLength}}
+	}
+
+	want := []int{signatureLength, recoverableSignatureLength}
+	if policy.RequireRecoveryID {
+		want = []int{recoverableSignatureLength}
+	}
+	if len(signature) != recoverableSignatureLength && (policy.RequireRecoveryID || len(signature) != signatureLength) {
+		return &subkey.LengthError{Err: subkey.ErrInvalidSignatureLength, Got: len(signature), Want: want}
+	}
+
+	sig := make([]byte, len(signature))
+	copy(sig, signature)
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if r.Sign() == 0 || s.Sign() == 0 || r.Cmp(secp256k1N) >= 0 || s.Cmp(secp256k1N) >= 0 {
+		return fmt.Errorf("%w: r or s out of range", subkey.ErrInvalidSignature)
+	}
+
+	highS := s.Cmp(secp256k1HalfN) > 0
+	if highS && policy.LowS {
+		return ErrHighS
+	}
+
+	if highS {
+		// normalise the malleable form so it can be checked like a low-S signature
+		s.Sub(secp256k1N, s)
+		s.FillBytes(sig[32:64])
+	}
+
+	if len(sig) == recoverableSignatureLength {
+		v := sig[64]
+		if policy.AllowEthereumV && (v == 27 || v == 28) {
+			v -= 27
+		}
+
+		if v > 1 {
+			return fmt.Errorf("%w: %d", ErrInvalidRecoveryID, sig[64])
+		}
+
+		if highS {
+			v ^= 1
+		}
+		sig[64] = v
+
+		recovered, err := secp256k1.SigToPub(digest, sig)
+		if err != nil {
+			return fmt.Errorf("%w: %v", subkey.ErrInvalidSignature, err)
+		}
+
+		if !bytes.Equal(secp256k1.CompressPubkey(recovered), pub.Public()) {
+			return ErrRecoveryMismatch
+		}
+	}
+
+	if !secp256k1.VerifySignature(pub.Public(), digest, sig[:signatureLength]) {
+		return subkey.ErrInvalidSignature
+	}
+
+	return nil
+}
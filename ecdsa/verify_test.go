@@ -0,0 +1,53 @@
+package ecdsa
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+func TestVerifyStrict(t *testing.T) {
+	kp, err := Scheme{}.Generate()
+	assert.NoError(t, err)
+	msg := []byte("intake")
+	sig, err := kp.Sign(msg)
+	assert.NoError(t, err)
+
+	lenient := VerifyPolicy{AllowEthereumV: true}
+	assert.NoError(t, VerifyStrict(kp, msg, sig, StrictPolicy))
+	assert.NoError(t, VerifyStrict(kp, msg, sig, lenient))
+	assert.ErrorIs(t, VerifyStrict(kp, []byte("other"), sig, StrictPolicy), subkey.ErrInvalidSignature)
+
+	// Verify checks [R || S] only, so 64 byte signatures are valid
+	assert.True(t, kp.Verify(msg, sig[:64]))
+	assert.False(t, kp.Verify(msg, sig[:63]))
+	assert.ErrorIs(t, VerifyStrict(kp, msg, sig[:64], StrictPolicy), subkey.ErrInvalidSignatureLength)
+	assert.NoError(t, VerifyStrict(kp, msg, sig[:64], lenient))
+	assert.ErrorIs(t, VerifyStrict(kp, msg, sig[:63], lenient), subkey.ErrInvalidSignatureLength)
+
+	eth := append([]byte{}, sig...)
+	eth[64] += 27
+	assert.ErrorIs(t, VerifyStrict(kp, msg, eth, StrictPolicy), ErrInvalidRecoveryID)
+	assert.NoError(t, VerifyStrict(kp, msg, eth, lenient))
+
+	badV := append([]byte{}, sig...)
+	badV[64] = 2
+	assert.ErrorIs(t, VerifyStrict(kp, msg, badV, lenient), ErrInvalidRecoveryID)
+
+	flipped := append([]byte{}, sig...)
+	flipped[64] ^= 1
+	assert.ErrorIs(t, VerifyStrict(kp, msg, flipped, StrictPolicy), ErrRecoveryMismatch)
+
+	highS := append([]byte{}, sig...)
+	s := new(big.Int).SetBytes(sig[32:64])
+	s.Sub(secp256k1N, s).FillBytes(highS[32:64])
+	highS[64] ^= 1
+	// Verify rejects the malleable form, VerifyPrehashed recovers the key like Substrate and accepts it
+	assert.False(t, kp.Verify(msg, highS))
+	digest := Blake2b256(msg)
+	assert.True(t, VerifyPrehashed(kp, digest[:], highS))
+	assert.ErrorIs(t, VerifyStrict(kp, msg, highS, StrictPolicy), ErrHighS)
+	assert.NoError(t, VerifyStrict(kp, msg, highS, lenient))
+}
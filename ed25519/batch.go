@@ -0,0 +1,39 @@
+package ed25519
+
+import (
+	"sort"
+
+	"github.com/hdevalence/ed25519consensus"
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/ed25519"
+)
+
+// VerifyBatch verifies the signatures with ZIP-215 batch verification.
+// It accepts exactly the signatures Verify accepts. If the batch fails,
+// the items are verified one by one to find the invalid ones.
+func (s Scheme) VerifyBatch(items []subkey.BatchItem) (failed []int) {
+	var indices []int
+	bv := ed25519consensus.NewPreallocatedBatchVerifier(len(items))
+	for i, item := range items {
+		if item.PublicKey == nil || len(item.PublicKey.Public()) != ed25519.PublicKeySize || len(item.Signature) != ed25519.SignatureSize {
+			failed = append(failed, i)
+			continue
+		}
+
+		indices = append(indices, i)
+		bv.Add(item.PublicKey.Public(), item.Message, item.Signature)
+	}
+
+	if len(indices) == 0 || bv.Verify() {
+		return failed
+	}
+
+	for _, i := range indices {
+		if !ed25519consensus.Verify(items[i].PublicKey.Public(), items[i].Message, items[i].Signature) {
+			failed = append(failed, i)
+		}
+	}
+
+	sort.Ints(failed)
+	return failed
+}
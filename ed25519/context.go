@@ -0,0 +1,58 @@
+package ed25519
+
+import (
+	"crypto"
+	stded25519 "crypto/ed25519"
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/ed25519"
+)
+
+// SignWithContext signs the message with Ed25519ctx under the context.
+// The context must be at most 255 bytes, an empty context yields a plain Ed25519 signature.
+func SignWithContext(pair subkey.KeyPair, context, msg []byte) ([]byte, error) {
+	return sign(pair, msg, &stded25519.Options{Context: string(context)})
+}
+
+// VerifyWithContext verifies the Ed25519ctx signature of the message under the context.
+func VerifyWithContext(pub subkey.PublicKey, context, msg, signature []byte) bool {
+	return verify(pub, msg, signature, &stded25519.Options{Context: string(context)})
+}
+
+// SignPrehashed signs the message with Ed25519ph under the optional context.
+// The message is hashed with SHA-512 before signing.
+func SignPrehashed(pair subkey.KeyPair, context, msg []byte) ([]byte, error) {
+	digest := sha512.Sum512(msg)
+	return sign(pair, digest[:], &stded25519.Options{Hash: crypto.SHA512, Context: string(context)})
+}
+
+// VerifyPrehashed verifies the Ed25519ph signature of the message under the optional context.
+func VerifyPrehashed(pub subkey.PublicKey, context, msg, signature []byte) bool {
+	digest := sha512.Sum512(msg)
+	return verify(pub, digest[:], signature, &stded25519.Options{Hash: crypto.SHA512, Context: string(context)})
+}
+
+func sign(pair subkey.KeyPair, msg []byte, opts *stded25519.Options) ([]byte, error) {
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := kr.secret.Sign(nil, msg, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+
+	return sig, nil
+}
+
+func verify(pub subkey.PublicKey, msg, signature []byte, opts *stded25519.Options) bool {
+	key := pub.Public()
+	if len(key) != ed25519.PublicKeySize {
+		return false
+	}
+
+	return stded25519.VerifyWithOptions(key, msg, signature, opts) == nil
+}
@@ -0,0 +1,34 @@
+package ed25519
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignWithContext(t *testing.T) {
+	kp, err := Scheme{}.Generate()
+	assert.NoError(t, err)
+	pub, err := Scheme{}.FromPublicKey(kp.Public())
+	assert.NoError(t, err)
+	msg := []byte("message")
+
+	sig, err := SignWithContext(kp, []byte("app"), msg)
+	assert.NoError(t, err)
+	assert.True(t, VerifyWithContext(pub, []byte("app"), msg, sig))
+	assert.False(t, VerifyWithContext(pub, []byte("other"), msg, sig))
+	assert.False(t, pub.Verify(msg, sig))
+
+	sig, err = SignPrehashed(kp, []byte("app"), msg)
+	assert.NoError(t, err)
+	assert.True(t, VerifyPrehashed(pub, []byte("app"), msg, sig))
+	assert.False(t, VerifyPrehashed(pub, nil, msg, sig))
+	assert.False(t, VerifyWithContext(pub, []byte("app"), msg, sig))
+
+	sig, err = SignPrehashed(kp, nil, msg)
+	assert.NoError(t, err)
+	assert.True(t, VerifyPrehashed(pub, nil, msg, sig))
+
+	_, err = SignWithContext(kp, make([]byte, 256), msg)
+	assert.Error(t, err)
+}
@@ -4,9 +4,10 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/rand"
-	"errors"
+	"fmt"
 
 	"github.com/ChainSafe/go-schnorrkel"
+	"github.com/hdevalence/ed25519consensus"
 	"github.com/vedhavyas/go-subkey/v2"
 	"github.com/vedhavyas/go-subkey/v2/scale"
 	"golang.org/x/crypto/blake2b"
@@ -19,11 +20,21 @@ type keyRing struct {
 }
 
 func (kr keyRing) Sign(msg []byte) (signature []byte, err error) {
+	if !kr.hasSecret() {
+		return nil, subkey.ErrMissingSecret
+	}
+
 	return kr.secret.Sign(nil, msg, crypto.Hash(0))
 }
 
+// Verify verifies the signature with the ZIP-215 rules of ed25519-zebra, which Substrate uses.
+// They accept some signatures of small order and non-canonical keys that RFC 8032 rejects.
 func (kr keyRing) Verify(msg []byte, signature []byte) bool {
-	return ed25519.Verify(*kr.pub, msg, signature)
+	if len(*kr.pub) != ed25519.PublicKeySize {
+		return false
+	}
+
+	return ed25519consensus.Verify(*kr.pub, msg, signature)
 }
 
 func (kr keyRing) Public() []byte {
@@ -31,6 +42,10 @@ func (kr keyRing) Public() []byte {
 }
 
 func (kr keyRing) Seed() []byte {
+	if !kr.hasSecret() {
+		return nil
+	}
+
 	return kr.secret.Seed()
 }
 
@@ -39,7 +54,34 @@ func (kr keyRing) AccountID() []byte {
 }
 
 func (kr keyRing) SS58Address(network uint16) string {
-	return subkey.SS58Encode(kr.AccountID(), network)
+	address, _ := subkey.SS58EncodeStrict(kr.AccountID(), network)
+	return address
+}
+
+// toKeyRing returns the keyRing behind a key pair produced by this scheme.
+func toKeyRing(pair subkey.KeyPair) (keyRing, error) {
+	var kr keyRing
+	switch v := pair.(type) {
+	case keyRing:
+		kr = v
+	case *keyRing:
+		if v == nil {
+			return kr, fmt.Errorf("%w: nil", subkey.ErrUnsupportedKeyPair)
+		}
+		kr = *v
+	default:
+		return kr, fmt.Errorf("%w: %T", subkey.ErrUnsupportedKeyPair, pair)
+	}
+
+	if !kr.hasSecret() {
+		return kr, subkey.ErrMissingSecret
+	}
+
+	return kr, nil
+}
+
+func init() {
+	subkey.RegisterScheme(Scheme{})
 }
 
 type Scheme struct{}
@@ -61,6 +103,10 @@ func (s Scheme) Generate() (subkey.KeyPair, error) {
 }
 
 func (s Scheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidSeedLength, Got: len(seed), Want: []int{ed25519.SeedSize}}
+	}
+
 	secret := ed25519.NewKeyFromSeed(seed)
 	pub := secret.Public().(ed25519.PublicKey)
 	return keyRing{
@@ -72,18 +118,22 @@ func (s Scheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
 func (s Scheme) FromPhrase(phrase, pwd string) (subkey.KeyPair, error) {
 	seed, err := schnorrkel.SeedFromMnemonic(phrase, pwd)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidPhrase, err)
 	}
 
 	return s.FromSeed(seed[:32])
 }
 
 func (s Scheme) Derive(pair subkey.KeyPair, djs []subkey.DeriveJunction) (subkey.KeyPair, error) {
-	acc := pair.(keyRing).secret.Seed()
-	var err error
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := kr.secret.Seed()
 	for _, dj := range djs {
 		if !dj.IsHard {
-			return nil, errors.New("soft derivation is not supported")
+			return nil, subkey.ErrSoftJunctionNotSupported
 		}
 
 		acc, err = deriveKeyHard(acc, dj.ChainCode)
@@ -117,7 +167,21 @@ func deriveKeyHard(secret []byte, cc [32]byte) ([]byte, error) {
 }
 
 func (s Scheme) FromPublicKey(bytes []byte) (subkey.PublicKey, error) {
+	if len(bytes) != ed25519.PublicKeySize {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidPublicKey, Got: len(bytes), Want: []int{ed25519.PublicKeySize}}
+	}
+
 	key := ed25519.PublicKey(bytes)
 	kr := keyRing{pub: &key}
 	return &kr, nil
 }
+
+// VerifyAccount verifies the signature against the account ID, which is the public key for this scheme.
+func (s Scheme) VerifyAccount(accountID, msg, signature []byte) bool {
+	pub, err := s.FromPublicKey(accountID)
+	if err != nil {
+		return false
+	}
+
+	return pub.Verify(msg, signature)
+}
@@ -0,0 +1,48 @@
+package ed25519
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// hasSecret reports whether the key ring holds a secret that was not wiped.
+func (kr keyRing) hasSecret() bool {
+	return kr.secret != nil && len(*kr.secret) != 0
+}
+
+// Wipe zeroes the secret key, which holds the seed.
+func (kr keyRing) Wipe() {
+	if kr.secret != nil {
+		subkey.Zero(*kr.secret)
+		*kr.secret = nil
+	}
+}
+
+// Close wipes the key ring.
+func (kr keyRing) Close() error {
+	kr.Wipe()
+	return nil
+}
+
+// String describes the key ring without its secret.
+func (kr keyRing) String() string {
+	return subkey.RedactedString(Scheme{}.String(), kr.Public(), kr.hasSecret())
+}
+
+// GoString describes the key ring without its secret.
+func (kr keyRing) GoString() string {
+	return kr.String()
+}
+
+// Format prints the key ring without its secret for every verb.
+func (kr keyRing) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, kr.String())
+}
+
+// LogValue logs the key ring without its secret.
+func (kr keyRing) LogValue() slog.Value {
+	return subkey.RedactedLogValue(Scheme{}.String(), kr.Public(), kr.hasSecret())
+}
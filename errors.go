@@ -0,0 +1,94 @@
+package subkey
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidSeedLength is returned when a seed has an unsupported length.
+	ErrInvalidSeedLength = errors.New("invalid seed length")
+	// ErrInvalidSeed is returned when a seed has the right length but is not a valid secret.
+	ErrInvalidSeed = errors.New("invalid seed")
+	// ErrInvalidPhrase is returned when a mnemonic phrase cannot be decoded.
+	ErrInvalidPhrase = errors.New("invalid mnemonic phrase")
+	// ErrInvalidPublicKey is returned when a public key cannot be decoded.
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	// ErrInvalidSignatureLength is returned when a signature has an unsupported length.
+	ErrInvalidSignatureLength = errors.New("invalid signature length")
+	// ErrInvalidDigestLength is returned when a prehashed message is not 32 bytes.
+	ErrInvalidDigestLength = errors.New("invalid digest length")
+	// ErrInvalidSignature is returned when a signature is malformed or does not recover to a public key.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidURI is returned when a secret URI is malformed.
+	ErrInvalidURI = errors.New("invalid URI")
+	// ErrInvalidAddress is returned when an SS58 address is malformed.
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrInvalidPayloadLength is returned when an SS58 payload has a length not covered by the spec.
+	ErrInvalidPayloadLength = errors.New("invalid SS58 payload length")
+	// ErrReservedFormat is returned for the reserved SS58 prefixes 46 and 47.
+	ErrReservedFormat = errors.New("reserved SS58 format")
+	// ErrInvalidFormat is returned for SS58 idents above 16383.
+	ErrInvalidFormat = errors.New("invalid SS58 format")
+	// ErrUnknownNetwork is returned when a network is not in the SS58 registry.
+	ErrUnknownNetwork = errors.New("unknown network")
+	// ErrUnknownScheme is returned when no scheme is registered with a name.
+	ErrUnknownScheme = errors.New("unknown scheme")
+	// ErrBadChecksum is returned when an SS58 address checksum does not match.
+	ErrBadChecksum = errors.New("checksum mismatch")
+	// ErrSoftJunctionNotSupported is returned when a scheme cannot derive soft junctions.
+	ErrSoftJunctionNotSupported = errors.New("soft derivation is not supported")
+	// ErrHardJunctionNotSupported is returned when hard junctions are used on public keys.
+	ErrHardJunctionNotSupported = errors.New("hard derivation is not supported for public keys")
+	// ErrUnsupportedKeyPair is returned when a scheme is given a KeyPair it did not produce.
+	ErrUnsupportedKeyPair = errors.New("unsupported key pair")
+	// ErrMissingSecret is returned when a secret is required but the key pair only holds a public key.
+	ErrMissingSecret = errors.New("key pair has no secret")
+	// ErrInvalidKeystore is returned when an encrypted key file is malformed or uses an unsupported encoding.
+	ErrInvalidKeystore = errors.New("invalid keystore")
+	// ErrInvalidPassword is returned when an encrypted key cannot be decrypted with the password.
+	ErrInvalidPassword = errors.New("invalid password")
+	// ErrInvalidKeyType is returned when a key type is not 4 bytes.
+	ErrInvalidKeyType = errors.New("invalid key type")
+	// ErrKeyNotFound is returned when a keystore has no key for the public key.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrKeyExists is returned when a keystore already has a key with the public key or label.
+	ErrKeyExists = errors.New("key already exists")
+)
+
+// LengthError is returned when an input has an unexpected length.
+// Err is one of the length sentinels, such as ErrInvalidSeedLength.
+type LengthError struct {
+	Err  error
+	Got  int
+	Want []int
+}
+
+func (e *LengthError) Error() string {
+	want := make([]string, len(e.Want))
+	for i, w := range e.Want {
+		want[i] = strconv.Itoa(w)
+	}
+
+	return fmt.Sprintf("%v: got %d, want %s", e.Err, e.Got, strings.Join(want, " or "))
+}
+
+func (e *LengthError) Unwrap() error {
+	return e.Err
+}
+
+// ChecksumError is returned when an SS58 checksum does not match.
+type ChecksumError struct {
+	Want []byte
+	Got  []byte
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%v: expected %v but got %v", ErrBadChecksum, e.Want, e.Got)
+}
+
+func (e *ChecksumError) Unwrap() error {
+	return ErrBadChecksum
+}
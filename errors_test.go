@@ -0,0 +1,81 @@
+package subkey_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func TestSchemeErrors(t *testing.T) {
+	soft, err := subkey.ParseSecretURI("/foo")
+	assert.NoError(t, err)
+	softJunctions, err := soft.DeriveJunctions()
+	assert.NoError(t, err)
+
+	tests := map[subkey.Scheme]struct {
+		seedLengths, publicKeyLengths []int
+		softJunctionErr               error
+	}{
+		sr25519.Scheme{}: {seedLengths: []int{32, 64}, publicKeyLengths: []int{32}},
+		ed25519.Scheme{}: {seedLengths: []int{32}, publicKeyLengths: []int{32}, softJunctionErr: subkey.ErrSoftJunctionNotSupported},
+		ecdsa.Scheme{}:   {seedLengths: []int{32}, publicKeyLengths: []int{33}, softJunctionErr: subkey.ErrSoftJunctionNotSupported},
+	}
+
+	for scheme, c := range tests {
+		_, err := scheme.FromSeed(make([]byte, 31))
+		assert.ErrorIs(t, err, subkey.ErrInvalidSeedLength)
+		var lerr *subkey.LengthError
+		assert.True(t, errors.As(err, &lerr))
+		assert.Equal(t, 31, lerr.Got)
+		assert.Equal(t, c.seedLengths, lerr.Want)
+
+		_, err = scheme.FromPublicKey(make([]byte, 12))
+		assert.ErrorIs(t, err, subkey.ErrInvalidPublicKey)
+		assert.True(t, errors.As(err, &lerr))
+		assert.Equal(t, c.publicKeyLengths, lerr.Want)
+
+		_, err = scheme.FromPhrase("not a valid phrase", "")
+		assert.ErrorIs(t, err, subkey.ErrInvalidPhrase)
+
+		kp, err := scheme.Generate()
+		assert.NoError(t, err)
+		// short and empty signatures are rejected without panicking
+		assert.False(t, kp.Verify([]byte("msg"), make([]byte, 12)))
+		assert.False(t, kp.Verify([]byte("msg"), nil))
+
+		_, err = scheme.Derive(kp, softJunctions)
+		if c.softJunctionErr == nil {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, c.softJunctionErr)
+		}
+
+		pub, err := scheme.FromPublicKey(kp.Public())
+		assert.NoError(t, err)
+		_, err = pub.(subkey.KeyPair).Sign([]byte("msg"))
+		assert.ErrorIs(t, err, subkey.ErrMissingSecret)
+		_, err = scheme.Derive(pub.(subkey.KeyPair), nil)
+		assert.ErrorIs(t, err, subkey.ErrMissingSecret)
+	}
+
+	// the zero scalar is not a valid secp256k1 key
+	_, err = ecdsa.Scheme{}.FromSeed(make([]byte, 32))
+	assert.ErrorIs(t, err, subkey.ErrInvalidSeed)
+}
+
+func TestSS58DecodeErrors(t *testing.T) {
+	_, _, err := subkey.SS58Decode("a8SvTrjvshEMePMEZpEkYMekuZMPpDwMNqfUx8N8ScEEQYfM8")
+	assert.ErrorIs(t, err, subkey.ErrBadChecksum)
+	var cerr *subkey.ChecksumError
+	assert.True(t, errors.As(err, &cerr))
+
+	for _, addr := range []string{"", "1", "0OIl", "5Gr"} {
+		_, _, err = subkey.SS58Decode(addr)
+		assert.ErrorIs(t, err, subkey.ErrInvalidAddress, addr)
+	}
+}
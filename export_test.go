@@ -0,0 +1,4 @@
+package subkey
+
+// UnregisterScheme exposes unregisterScheme to the external tests.
+var UnregisterScheme = unregisterScheme
@@ -4,15 +4,17 @@ go 1.24.1
 
 require (
 	github.com/ChainSafe/go-schnorrkel v1.0.0
+	github.com/cosmos/go-bip39 v1.0.0
 	github.com/decred/base58 v1.0.4
 	github.com/ethereum/go-ethereum v1.15.5
 	github.com/gtank/merlin v0.1.1
+	github.com/hdevalence/ed25519consensus v0.2.0
 	github.com/stretchr/testify v1.10.0
 	golang.org/x/crypto v0.32.0
 )
 
 require (
-	github.com/cosmos/go-bip39 v1.0.0 // indirect
+	filippo.io/edwards25519 v1.0.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/decred/dcrd/crypto/blake256 v1.0.0 // indirect
 	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.1.0 // indirect
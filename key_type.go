@@ -0,0 +1,50 @@
+package subkey
+
+import "fmt"
+
+// KeyTypeID identifies the role of a key in Substrate, such as babe or gran.
+type KeyTypeID [4]byte
+
+// The key types of the session keys of Substrate and Polkadot nodes.
+var (
+	KeyTypeBabe               = KeyTypeID{'b', 'a', 'b', 'e'}
+	KeyTypeGrandpa            = KeyTypeID{'g', 'r', 'a', 'n'}
+	KeyTypeImOnline           = KeyTypeID{'i', 'm', 'o', 'n'}
+	KeyTypeParachainValidator = KeyTypeID{'p', 'a', 'r', 'a'}
+	KeyTypeAssignment         = KeyTypeID{'a', 's', 'g', 'n'}
+	KeyTypeAuthorityDiscovery = KeyTypeID{'a', 'u', 'd', 'i'}
+	KeyTypeBeefy              = KeyTypeID{'b', 'e', 'e', 'f'}
+	KeyTypeAura               = KeyTypeID{'a', 'u', 'r', 'a'}
+)
+
+// ParseKeyTypeID returns the key type of the 4 character name, such as "babe".
+func ParseKeyTypeID(s string) (KeyTypeID, error) {
+	var id KeyTypeID
+	if len(s) != len(id) {
+		return id, fmt.Errorf("%w: %q is not 4 bytes", ErrInvalidKeyType, s)
+	}
+
+	copy(id[:], s)
+	return id, nil
+}
+
+// String returns the 4 character name of the key type.
+func (id KeyTypeID) String() string {
+	return string(id[:])
+}
+
+// MarshalText encodes the key type as its name.
+func (id KeyTypeID) MarshalText() ([]byte, error) {
+	return id[:], nil
+}
+
+// UnmarshalText parses the 4 character name of the key type.
+func (id *KeyTypeID) UnmarshalText(text []byte) error {
+	parsed, err := ParseKeyTypeID(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
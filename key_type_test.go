@@ -0,0 +1,26 @@
+package subkey_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+func TestKeyTypeID(t *testing.T) {
+	id, err := subkey.ParseKeyTypeID("babe")
+	assert.NoError(t, err)
+	assert.Equal(t, subkey.KeyTypeBabe, id)
+	assert.Equal(t, "gran", subkey.KeyTypeGrandpa.String())
+
+	_, err = subkey.ParseKeyTypeID("grandpa")
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeyType)
+
+	b, err := json.Marshal(subkey.KeyTypeBeefy)
+	assert.NoError(t, err)
+	assert.Equal(t, `"beef"`, string(b))
+	assert.NoError(t, json.Unmarshal([]byte(`"audi"`), &id))
+	assert.Equal(t, subkey.KeyTypeAuthorityDiscovery, id)
+	assert.Error(t, json.Unmarshal([]byte(`"aud"`), &id))
+}
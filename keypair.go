@@ -11,6 +11,7 @@ type PublicKey interface {
 	AccountID() []byte
 
 	// SS58Address returns the Base58 public key with checksum and network identifier.
+	// It returns an empty string if the network is not a valid SS58 format, see SS58EncodeStrict.
 	SS58Address(network uint16) string
 }
 
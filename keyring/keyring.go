@@ -0,0 +1,183 @@
+// Package keyring provides the well-known development accounts of Substrate's sp_keyring.
+// The key pairs are derived from DevPhrase, so they match sp_keyring byte for byte.
+package keyring
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// Account is a development account such as Alice or AliceStash.
+type Account string
+
+// The accounts of sp_keyring, each derived from DevPhrase with the junctions of its URI.
+const (
+	Alice        Account = "Alice"
+	Bob          Account = "Bob"
+	Charlie      Account = "Charlie"
+	Dave         Account = "Dave"
+	Eve          Account = "Eve"
+	Ferdie       Account = "Ferdie"
+	One          Account = "One"
+	Two          Account = "Two"
+	AliceStash   Account = "AliceStash"
+	BobStash     Account = "BobStash"
+	CharlieStash Account = "CharlieStash"
+	DaveStash    Account = "DaveStash"
+	EveStash     Account = "EveStash"
+	FerdieStash  Account = "FerdieStash"
+)
+
+const stashSuffix = "Stash"
+
+var accounts = []Account{
+	Alice, Bob, Charlie, Dave, Eve, Ferdie, One, Two,
+	AliceStash, BobStash, CharlieStash, DaveStash, EveStash, FerdieStash,
+}
+
+type cacheKey struct {
+	scheme  string
+	account Account
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = make(map[cacheKey]subkey.KeyPair)
+)
+
+// Accounts returns every account, the base accounts first and then the stash accounts.
+func Accounts() []Account {
+	list := make([]Account, len(accounts))
+	copy(list, accounts)
+	return list
+}
+
+// Lookup returns the account with the name, such as "alice", "AliceStash" or "//Alice//stash".
+// Names are case-insensitive, URIs must match exactly since junctions are case-sensitive.
+func Lookup(name string) (Account, bool) {
+	for _, a := range accounts {
+		if strings.EqualFold(name, string(a)) || name == a.URI() {
+			return a, true
+		}
+	}
+
+	return "", false
+}
+
+// ByPublic returns the account whose key pair of the scheme has the public key.
+func ByPublic(scheme subkey.Scheme, public []byte) (Account, bool) {
+	return find(scheme, func(kp subkey.KeyPair) bool {
+		return bytes.Equal(kp.Public(), public)
+	})
+}
+
+// ByAccountID returns the account whose key pair of the scheme has the account ID.
+func ByAccountID(scheme subkey.Scheme, accountID []byte) (Account, bool) {
+	return find(scheme, func(kp subkey.KeyPair) bool {
+		return bytes.Equal(kp.AccountID(), accountID)
+	})
+}
+
+// ByAddress returns the account of the scheme with the SS58 address on any network.
+func ByAddress(scheme subkey.Scheme, address string) (Account, bool) {
+	_, accountID, err := subkey.SS58Decode(address)
+	if err != nil {
+		return "", false
+	}
+
+	return ByAccountID(scheme, accountID)
+}
+
+func find(scheme subkey.Scheme, match func(subkey.KeyPair) bool) (Account, bool) {
+	for _, a := range accounts {
+		kp, err := a.KeyPair(scheme)
+		if err == nil && match(kp) {
+			return a, true
+		}
+	}
+
+	return "", false
+}
+
+// URI returns the secret URI of the account, such as "//Alice" or "//Alice//stash".
+func (a Account) URI() string {
+	if base := strings.TrimSuffix(string(a), stashSuffix); base != string(a) {
+		return "//" + base + "//stash"
+	}
+
+	return "//" + string(a)
+}
+
+func (a Account) known() bool {
+	for _, known := range accounts {
+		if a == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the name of the account.
+func (a Account) String() string {
+	return string(a)
+}
+
+// KeyPair returns the key pair of the account for the scheme.
+// The derivation is done once per scheme and every call returns a fresh copy,
+// so wiping a key pair does not affect other callers.
+func (a Account) KeyPair(scheme subkey.Scheme) (subkey.KeyPair, error) {
+	if !a.known() {
+		return nil, fmt.Errorf("%w: unknown account %q", subkey.ErrKeyNotFound, string(a))
+	}
+
+	key := cacheKey{scheme: scheme.String(), account: a}
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	kp, ok := cache[key]
+	if !ok {
+		var err error
+		if kp, err = subkey.DeriveKeyPair(scheme, a.URI()); err != nil {
+			return nil, err
+		}
+
+		cache[key] = kp
+	}
+
+	// the junctions of every account are hard, so the seed restores the key pair
+	return scheme.FromSeed(kp.Seed())
+}
+
+// MustKeyPair is like KeyPair but panics on errors. It is meant for tests.
+func (a Account) MustKeyPair(scheme subkey.Scheme) subkey.KeyPair {
+	kp, err := a.KeyPair(scheme)
+	if err != nil {
+		panic(err)
+	}
+
+	return kp
+}
+
+// Public returns the public key of the account for the scheme.
+func (a Account) Public(scheme subkey.Scheme) ([]byte, error) {
+	kp, err := a.KeyPair(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return kp.Public(), nil
+}
+
+// SS58Address returns the address of the account for the scheme on the network.
+func (a Account) SS58Address(scheme subkey.Scheme, network uint16) (string, error) {
+	kp, err := a.KeyPair(scheme)
+	if err != nil {
+		return "", err
+	}
+
+	return kp.SS58Address(network), nil
+}
@@ -0,0 +1,108 @@
+package keyring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func fromHex(t *testing.T, hex string) []byte {
+	bytes, success := subkey.DecodeHex(hex)
+	assert.True(t, success)
+	return bytes
+}
+
+func TestKeyPair(t *testing.T) {
+	// public keys of sp_keyring
+	tests := []struct {
+		scheme  subkey.Scheme
+		account Account
+		public  string
+	}{
+		{sr25519.Scheme{}, Alice, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"},
+		{sr25519.Scheme{}, Bob, "0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"},
+		{sr25519.Scheme{}, Charlie, "0x90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22"},
+		{sr25519.Scheme{}, AliceStash, "0xbe5ddb1579b72e84524fc29e78609e3caf42e85aa118ebfe0b0ad404b5bdd25f"},
+		{sr25519.Scheme{}, BobStash, "0xfe65717dad0447d715f660a0a58411de509b42e6efb8375f562f58a554d5860e"},
+		{ed25519.Scheme{}, Alice, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee"},
+		{ecdsa.Scheme{}, Alice, "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1"},
+	}
+
+	for _, tt := range tests {
+		kp, err := tt.account.KeyPair(tt.scheme)
+		assert.NoError(t, err)
+		assert.Equal(t, fromHex(t, tt.public), kp.Public(), tt.account)
+
+		// every call returns an equal copy
+		assert.Equal(t, kp, tt.account.MustKeyPair(tt.scheme))
+
+		a, ok := ByPublic(tt.scheme, kp.Public())
+		assert.True(t, ok)
+		assert.Equal(t, tt.account, a)
+		a, ok = ByAddress(tt.scheme, kp.SS58Address(0))
+		assert.True(t, ok)
+		assert.Equal(t, tt.account, a)
+	}
+
+	addr, err := Alice.SS58Address(sr25519.Scheme{}, 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", addr)
+
+	_, err = Account("Mallory").KeyPair(sr25519.Scheme{})
+	assert.ErrorIs(t, err, subkey.ErrKeyNotFound)
+	_, err = Account("alice").KeyPair(sr25519.Scheme{})
+	assert.ErrorIs(t, err, subkey.ErrKeyNotFound)
+	assert.Panics(t, func() { Account("Mallory").MustKeyPair(sr25519.Scheme{}) })
+
+	_, ok := ByAddress(sr25519.Scheme{}, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	assert.True(t, ok)
+	_, ok = ByAddress(ed25519.Scheme{}, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	assert.False(t, ok)
+	_, ok = ByAddress(sr25519.Scheme{}, "invalid")
+	assert.False(t, ok)
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  Account
+		found bool
+	}{
+		{name: "alice", want: Alice, found: true},
+		{name: "Ferdie", want: Ferdie, found: true},
+		{name: "two", want: Two, found: true},
+		{name: "DaveStash", want: DaveStash, found: true},
+		{name: "ferdiestash", want: FerdieStash, found: true},
+		{name: "//Eve//stash", want: EveStash, found: true},
+		{name: "//Charlie", want: Charlie, found: true},
+		// junctions are case-sensitive, so URIs must match exactly
+		{name: "//charlie"},
+		{name: "//Alice//Stash"},
+		{name: "Mallory"},
+	}
+
+	for _, c := range tests {
+		got, ok := Lookup(c.name)
+		assert.Equal(t, c.found, ok, c.name)
+		assert.Equal(t, c.want, got, c.name)
+	}
+
+	assert.Equal(t, "//Bob//stash", BobStash.URI())
+	assert.Equal(t, "//One", One.URI())
+	assert.Len(t, Accounts(), 14)
+}
+
+func TestKeyPairWipe(t *testing.T) {
+	kp := Bob.MustKeyPair(sr25519.Scheme{})
+	subkey.Wipe(kp)
+	assert.Nil(t, kp.Seed())
+
+	kp = Bob.MustKeyPair(sr25519.Scheme{})
+	assert.NotNil(t, kp.Seed())
+	_, err := kp.Sign([]byte("msg"))
+	assert.NoError(t, err)
+}
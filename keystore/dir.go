@@ -0,0 +1,433 @@
+package keystore
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	dirVersion = 1
+
+	// configFile holds the KDF parameters and a check value for the passphrase.
+	configFile = "keystore.json"
+	keyFileExt = ".json"
+
+	kdfArgon2id = "argon2id"
+	kdfScrypt   = "scrypt"
+
+	saltLength = 32
+
+	// keystore.json is not authenticated before the key is derived, so its parameters are
+	// capped at four times Argon2idParams and ScryptParams: a tampered configuration makes
+	// Open at most a few times slower and hungrier than usual.
+	maxArgon2Time    = 12
+	maxArgon2Memory  = 256 << 10
+	maxArgon2Threads = 16
+	maxScryptN       = 1 << 17
+	maxScryptR       = 8
+	maxScryptP       = 4
+)
+
+// checkPlaintext is encrypted into the configuration to detect a wrong passphrase on Open.
+var checkPlaintext = []byte("go-subkey keystore")
+
+// KDFParams selects how the passphrase of a directory keystore is stretched into its encryption key.
+type KDFParams struct {
+	// Name is "argon2id" or "scrypt".
+	Name string `json:"name"`
+	Salt []byte `json:"salt,omitempty"`
+
+	// Time, Memory in KiB and Threads are the argon2id parameters.
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+
+	// N, R and P are the scrypt parameters.
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+}
+
+var (
+	// Argon2idParams are the argon2id parameters recommended by RFC 9106 for memory constrained environments.
+	Argon2idParams = KDFParams{Name: kdfArgon2id, Time: 3, Memory: 64 << 10, Threads: 4}
+	// ScryptParams are the scrypt parameters recommended for interactive use.
+	ScryptParams = KDFParams{Name: kdfScrypt, N: 1 << 15, R: 8, P: 1}
+)
+
+func (p KDFParams) deriveKey(passphrase string) ([]byte, error) {
+	switch p.Name {
+	case kdfArgon2id:
+		// argon2 needs at least 8 KiB of memory per thread
+		if p.Time < 1 || p.Time > maxArgon2Time || p.Threads < 1 || p.Threads > maxArgon2Threads ||
+			p.Memory < 8*uint32(p.Threads) || p.Memory > maxArgon2Memory {
+			return nil, fmt.Errorf("%w: argon2id parameters out of range", subkey.ErrInvalidKeystore)
+		}
+
+		return argon2.IDKey([]byte(passphrase), p.Salt, p.Time, p.Memory, p.Threads, chacha20poly1305.KeySize), nil
+	case kdfScrypt:
+		if p.N <= 1 || p.N&(p.N-1) != 0 || p.N > maxScryptN || p.R < 1 || p.R > maxScryptR || p.P < 1 || p.P > maxScryptP {
+			return nil, fmt.Errorf("%w: scrypt parameters out of range", subkey.ErrInvalidKeystore)
+		}
+
+		key, err := scrypt.Key([]byte(passphrase), p.Salt, p.N, p.R, p.P, chacha20poly1305.KeySize)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+		}
+
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported kdf %q", subkey.ErrInvalidKeystore, p.Name)
+}
+
+type dirConfig struct {
+	Version int       `json:"version"`
+	KDF     KDFParams `json:"kdf"`
+	Nonce   []byte    `json:"nonce"`
+	Check   []byte    `json:"check"`
+}
+
+type keyFile struct {
+	Scheme     string           `json:"scheme"`
+	Type       subkey.KeyTypeID `json:"keyType"`
+	Public     string           `json:"public"`
+	Label      string           `json:"label,omitempty"`
+	Nonce      []byte           `json:"nonce"`
+	Ciphertext []byte           `json:"ciphertext"`
+}
+
+// Dir is a Keystore that keeps every key in its own encrypted file of a directory.
+// The passphrase is stretched once on Open, and the seeds are encrypted with XChaCha20-Poly1305
+// bound to the scheme, key type and public key of their file.
+type Dir struct {
+	mu   sync.RWMutex
+	dir  string
+	aead cipher.AEAD
+}
+
+var _ Keystore = (*Dir)(nil)
+
+// OpenDir opens the keystore in dir with the passphrase, creating it if needed.
+// The KDF parameters are only used for a new keystore, existing ones keep theirs.
+// ErrInvalidPassword is returned if the passphrase does not match the keystore.
+func OpenDir(dir, passphrase string, kdf KDFParams) (*Dir, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	var cfg dirConfig
+	data, err := os.ReadFile(filepath.Join(dir, configFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return createDir(dir, passphrase, kdf)
+	case err != nil:
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	if cfg.Version != dirVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", subkey.ErrInvalidKeystore, cfg.Version)
+	}
+
+	d, err := newDir(dir, passphrase, cfg.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Nonce) != d.aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", subkey.ErrInvalidKeystore)
+	}
+
+	if _, err := d.aead.Open(nil, cfg.Nonce, cfg.Check, checkPlaintext); err != nil {
+		return nil, subkey.ErrInvalidPassword
+	}
+
+	return d, nil
+}
+
+func createDir(dir, passphrase string, kdf KDFParams) (*Dir, error) {
+	kdf.Salt = make([]byte, saltLength)
+	if _, err := rand.Read(kdf.Salt); err != nil {
+		return nil, err
+	}
+
+	d, err := newDir(dir, passphrase, kdf)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := dirConfig{Version: dirVersion, KDF: kdf, Nonce: make([]byte, d.aead.NonceSize())}
+	if _, err := rand.Read(cfg.Nonce); err != nil {
+		return nil, err
+	}
+
+	cfg.Check = d.aead.Seal(nil, cfg.Nonce, checkPlaintext, checkPlaintext)
+	return d, writeJSON(filepath.Join(dir, configFile), cfg)
+}
+
+func newDir(dir, passphrase string, kdf KDFParams) (*Dir, error) {
+	key, err := kdf.deriveKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dir{dir: dir, aead: aead}, nil
+}
+
+func (d *Dir) Generate(scheme subkey.Scheme, keyType subkey.KeyTypeID, label string) (Key, error) {
+	if scheme == nil {
+		return Key{}, fmt.Errorf("%w: nil", subkey.ErrUnknownScheme)
+	}
+
+	pair, err := scheme.Generate()
+	if err != nil {
+		return Key{}, err
+	}
+
+	return d.Import(scheme, keyType, label, pair)
+}
+
+func (d *Dir) Import(scheme subkey.Scheme, keyType subkey.KeyTypeID, label string, pair subkey.KeyPair) (Key, error) {
+	name, err := schemeName(scheme)
+	if err != nil {
+		return Key{}, err
+	}
+
+	seed, err := seedOf(scheme, pair)
+	if err != nil {
+		return Key{}, err
+	}
+	defer subkey.Zero(seed)
+
+	// file the key under the public key of the seed that is stored, like Memory does
+	restored, err := scheme.FromSeed(seed)
+	if err != nil {
+		return Key{}, err
+	}
+	public := restored.Public()
+	subkey.Wipe(restored)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	keys, err := d.list()
+	if err != nil {
+		return Key{}, err
+	}
+
+	if err := checkNew(keys, public, label); err != nil {
+		return Key{}, err
+	}
+
+	kf := keyFile{
+		Scheme: name,
+		Type:   keyType,
+		Public: hex.EncodeToString(public),
+		Label:  label,
+		Nonce:  make([]byte, d.aead.NonceSize()),
+	}
+	if _, err := rand.Read(kf.Nonce); err != nil {
+		return Key{}, err
+	}
+
+	kf.Ciphertext = d.aead.Seal(nil, kf.Nonce, seed, kf.additionalData())
+	if err := writeJSON(d.path(public), kf); err != nil {
+		return Key{}, err
+	}
+
+	return Key{Scheme: scheme, Type: keyType, Public: public, Label: label}, nil
+}
+
+func (d *Dir) List() ([]Key, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	keys, err := d.list()
+	if err != nil {
+		return nil, err
+	}
+
+	sortKeys(keys)
+	return keys, nil
+}
+
+func (d *Dir) Get(public []byte) (Key, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	kf, err := d.read(public)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return kf.key()
+}
+
+func (d *Dir) GetByLabel(label string) (Key, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	keys, err := d.list()
+	if err != nil {
+		return Key{}, err
+	}
+
+	return findByLabel(keys, label)
+}
+
+func (d *Dir) Sign(public, msg []byte) ([]byte, error) {
+	d.mu.RLock()
+	kf, err := d.read(public)
+	d.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := kf.key()
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := d.aead.Open(nil, kf.Nonce, kf.Ciphertext, kf.additionalData())
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot decrypt %s", subkey.ErrInvalidKeystore, kf.Public)
+	}
+	defer subkey.Zero(seed)
+
+	pair, err := key.Scheme.FromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	defer subkey.Wipe(pair)
+
+	return pair.Sign(msg)
+}
+
+func (d *Dir) Delete(public []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	err := os.Remove(d.path(public))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", subkey.ErrKeyNotFound, subkey.EncodeHex(public))
+	}
+
+	return err
+}
+
+func (d *Dir) path(public []byte) string {
+	return filepath.Join(d.dir, hex.EncodeToString(public)+keyFileExt)
+}
+
+func (d *Dir) read(public []byte) (keyFile, error) {
+	var kf keyFile
+	data, err := os.ReadFile(d.path(public))
+	if errors.Is(err, fs.ErrNotExist) {
+		return kf, fmt.Errorf("%w: %s", subkey.ErrKeyNotFound, subkey.EncodeHex(public))
+	}
+	if err != nil {
+		return kf, err
+	}
+
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return kf, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	return kf, nil
+}
+
+func (d *Dir) list() ([]Key, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []Key
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == configFile || !strings.HasSuffix(name, keyFileExt) {
+			continue
+		}
+
+		public, err := hex.DecodeString(strings.TrimSuffix(name, keyFileExt))
+		if err != nil {
+			continue
+		}
+
+		kf, err := d.read(public)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := kf.key()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (kf keyFile) key() (Key, error) {
+	scheme, err := subkey.SchemeByName(kf.Scheme)
+	if err != nil {
+		return Key{}, err
+	}
+
+	public, err := hex.DecodeString(kf.Public)
+	if err != nil {
+		return Key{}, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	return Key{Scheme: scheme, Type: kf.Type, Public: public, Label: kf.Label}, nil
+}
+
+// additionalData binds the ciphertext to the scheme, key type and public key of the file.
+func (kf keyFile) additionalData() []byte {
+	return []byte(strings.ToLower(kf.Scheme) + "/" + kf.Type.String() + "/" + kf.Public)
+}
+
+// writeJSON writes the value through a temporary file so readers never see a partial file.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
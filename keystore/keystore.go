@@ -0,0 +1,118 @@
+// Package keystore keeps key pairs behind a Keystore so services can sign with keys by reference.
+// Keys are tagged with their scheme, their KeyTypeID and an optional label.
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	_ "github.com/vedhavyas/go-subkey/v2/ecdsa"   // registers the scheme for keys loaded from disk
+	_ "github.com/vedhavyas/go-subkey/v2/ed25519" // registers the scheme for keys loaded from disk
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+// Key describes a stored key. It never holds the secret.
+type Key struct {
+	Scheme subkey.Scheme
+	Type   subkey.KeyTypeID
+	Public []byte
+	// Label is an optional name, unique within the keystore.
+	Label string
+}
+
+func (k Key) clone() Key {
+	k.Public = append([]byte(nil), k.Public...)
+	return k
+}
+
+// Keystore stores key pairs and signs with them by public key.
+// Public keys and non empty labels are unique within a keystore.
+// ErrKeyNotFound is returned for unknown keys and ErrKeyExists for duplicates.
+type Keystore interface {
+	// Generate stores a new key pair of the scheme.
+	Generate(scheme subkey.Scheme, keyType subkey.KeyTypeID, label string) (Key, error)
+	// Import stores the key pair of the scheme.
+	Import(scheme subkey.Scheme, keyType subkey.KeyTypeID, label string, pair subkey.KeyPair) (Key, error)
+	// List returns the keys ordered by public key.
+	List() ([]Key, error)
+	// Get returns the key with the public key.
+	Get(public []byte) (Key, error)
+	// GetByLabel returns the key with the label.
+	GetByLabel(label string) (Key, error)
+	// Sign signs msg with the key pair of the public key.
+	Sign(public, msg []byte) ([]byte, error)
+	// Delete removes the key with the public key.
+	Delete(public []byte) error
+}
+
+// seedOf returns the seed the key pair is restored from with scheme.FromSeed.
+// Soft derived sr25519 key pairs have no seed, so their 64 byte secret key is used instead.
+func seedOf(scheme subkey.Scheme, pair subkey.KeyPair) ([]byte, error) {
+	if seed := pair.Seed(); seed != nil {
+		return seed, nil
+	}
+
+	if _, ok := scheme.(sr25519.Scheme); ok {
+		b, err := sr25519.Ed25519Bytes(pair)
+		if err != nil {
+			return nil, err
+		}
+
+		kp, err := sr25519.FromEd25519Bytes(b)
+		if err != nil {
+			return nil, err
+		}
+
+		return kp.Seed(), nil
+	}
+
+	return nil, subkey.ErrMissingSecret
+}
+
+// schemeName returns the registered name of the scheme.
+func schemeName(scheme subkey.Scheme) (string, error) {
+	if scheme == nil {
+		return "", fmt.Errorf("%w: nil", subkey.ErrUnknownScheme)
+	}
+
+	if _, err := subkey.SchemeByName(scheme.String()); err != nil {
+		return "", err
+	}
+
+	return scheme.String(), nil
+}
+
+// checkNew returns ErrKeyExists if the public key or the label is already used by a key.
+func checkNew(keys []Key, public []byte, label string) error {
+	for _, k := range keys {
+		if bytes.Equal(k.Public, public) {
+			return fmt.Errorf("%w: %s", subkey.ErrKeyExists, subkey.EncodeHex(public))
+		}
+
+		if label != "" && k.Label == label {
+			return fmt.Errorf("%w: label %q", subkey.ErrKeyExists, label)
+		}
+	}
+
+	return nil
+}
+
+func findByLabel(keys []Key, label string) (Key, error) {
+	if label != "" {
+		for _, k := range keys {
+			if k.Label == label {
+				return k, nil
+			}
+		}
+	}
+
+	return Key{}, fmt.Errorf("%w: label %q", subkey.ErrKeyNotFound, label)
+}
+
+func sortKeys(keys []Key) {
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Public, keys[j].Public) < 0
+	})
+}
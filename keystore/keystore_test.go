@@ -0,0 +1,195 @@
+package keystore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+// cheap parameters to keep the tests fast
+var (
+	testArgon2id = KDFParams{Name: kdfArgon2id, Time: 1, Memory: 1 << 10, Threads: 1}
+	testScrypt   = KDFParams{Name: kdfScrypt, N: 1 << 10, R: 8, P: 1}
+)
+
+func testKeystores(t *testing.T) map[string]Keystore {
+	argon, err := OpenDir(t.TempDir(), "passphrase", testArgon2id)
+	assert.NoError(t, err)
+	scr, err := OpenDir(t.TempDir(), "passphrase", testScrypt)
+	assert.NoError(t, err)
+
+	return map[string]Keystore{
+		"memory":   NewMemory(),
+		"argon2id": argon,
+		"scrypt":   scr,
+	}
+}
+
+func TestKeystore(t *testing.T) {
+	msg := []byte("msg")
+	for name, ks := range testKeystores(t) {
+		t.Run(name, func(t *testing.T) {
+			var keys []Key
+			for _, scheme := range []subkey.Scheme{sr25519.Scheme{}, ed25519.Scheme{}, ecdsa.Scheme{}, ecdsa.EthereumScheme{}} {
+				key, err := ks.Generate(scheme, subkey.KeyTypeBabe, "generated-"+scheme.String())
+				assert.NoError(t, err)
+				assert.Equal(t, scheme, key.Scheme)
+				keys = append(keys, key)
+
+				sig, err := ks.Sign(key.Public, msg)
+				assert.NoError(t, err)
+				pub, err := scheme.FromPublicKey(key.Public)
+				assert.NoError(t, err)
+				assert.True(t, pub.Verify(msg, sig))
+			}
+
+			// soft derived sr25519 key pairs have no seed
+			alice, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Alice/soft")
+			assert.NoError(t, err)
+			key, err := ks.Import(sr25519.Scheme{}, subkey.KeyTypeImOnline, "alice", alice)
+			assert.NoError(t, err)
+			assert.Equal(t, alice.Public(), key.Public)
+			sig, err := ks.Sign(alice.Public(), msg)
+			assert.NoError(t, err)
+			assert.True(t, alice.Verify(msg, sig))
+
+			got, err := ks.Get(alice.Public())
+			assert.NoError(t, err)
+			assert.Equal(t, key, got)
+			got, err = ks.GetByLabel("alice")
+			assert.NoError(t, err)
+			assert.Equal(t, key, got)
+			assert.Equal(t, subkey.KeyTypeImOnline, got.Type)
+
+			list, err := ks.List()
+			assert.NoError(t, err)
+			assert.Len(t, list, 5)
+			for i := 1; i < len(list); i++ {
+				assert.True(t, string(list[i-1].Public) < string(list[i].Public))
+			}
+
+			_, err = ks.Import(sr25519.Scheme{}, subkey.KeyTypeBabe, "", alice)
+			assert.ErrorIs(t, err, subkey.ErrKeyExists)
+			bob, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Bob")
+			assert.NoError(t, err)
+			_, err = ks.Import(sr25519.Scheme{}, subkey.KeyTypeBabe, "alice", bob)
+			assert.ErrorIs(t, err, subkey.ErrKeyExists)
+			pub, err := sr25519.Scheme{}.FromPublicKey(bob.Public())
+			assert.NoError(t, err)
+			_, err = ks.Import(sr25519.Scheme{}, subkey.KeyTypeBabe, "", pub.(subkey.KeyPair))
+			assert.ErrorIs(t, err, subkey.ErrMissingSecret)
+
+			assert.NoError(t, ks.Delete(alice.Public()))
+			_, err = ks.Get(alice.Public())
+			assert.ErrorIs(t, err, subkey.ErrKeyNotFound)
+			_, err = ks.GetByLabel("alice")
+			assert.ErrorIs(t, err, subkey.ErrKeyNotFound)
+			_, err = ks.Sign(alice.Public(), msg)
+			assert.ErrorIs(t, err, subkey.ErrKeyNotFound)
+			assert.ErrorIs(t, ks.Delete(alice.Public()), subkey.ErrKeyNotFound)
+			_, err = ks.GetByLabel("")
+			assert.ErrorIs(t, err, subkey.ErrKeyNotFound)
+
+			// keys are filed under the public key of their seed
+			charlie, err := subkey.DeriveKeyPair(ed25519.Scheme{}, "//Charlie")
+			assert.NoError(t, err)
+			key, err = ks.Import(ed25519.Scheme{}, subkey.KeyTypeBabe, "charlie", mismatchedPair{charlie, bob.Public()})
+			assert.NoError(t, err)
+			assert.Equal(t, charlie.Public(), key.Public)
+			sig, err = ks.Sign(charlie.Public(), msg)
+			assert.NoError(t, err)
+			assert.True(t, charlie.Verify(msg, sig))
+		})
+	}
+}
+
+// mismatchedPair is a key pair whose public key does not belong to its seed.
+type mismatchedPair struct {
+	subkey.KeyPair
+	public []byte
+}
+
+func (p mismatchedPair) Public() []byte {
+	return p.public
+}
+
+func TestOpenDir(t *testing.T) {
+	dir := t.TempDir()
+	ks, err := OpenDir(dir, "passphrase", testScrypt)
+	assert.NoError(t, err)
+	key, err := ks.Generate(ed25519.Scheme{}, subkey.KeyTypeGrandpa, "gran")
+	assert.NoError(t, err)
+
+	// existing keystores keep their parameters
+	ks, err = OpenDir(dir, "passphrase", KDFParams{Name: "unknown"})
+	assert.NoError(t, err)
+	got, err := ks.GetByLabel("gran")
+	assert.NoError(t, err)
+	assert.Equal(t, key, got)
+	_, err = ks.Sign(key.Public, []byte("msg"))
+	assert.NoError(t, err)
+
+	_, err = OpenDir(dir, "wrong", testScrypt)
+	assert.ErrorIs(t, err, subkey.ErrInvalidPassword)
+
+	// ciphertexts are bound to the metadata of their file
+	path := filepath.Join(dir, subkey.EncodeHex(key.Public)[2:]+keyFileExt)
+	kf, err := ks.read(key.Public)
+	assert.NoError(t, err)
+	kf.Type = subkey.KeyTypeBabe
+	assert.NoError(t, writeJSON(path, kf))
+	_, err = ks.Sign(key.Public, []byte("msg"))
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o600))
+	list, err := ks.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	_, err = OpenDir(t.TempDir(), "passphrase", KDFParams{Name: "pbkdf2"})
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+}
+
+func TestOpenDirKDFParams(t *testing.T) {
+	tests := []struct {
+		kdf   string
+		valid bool
+	}{
+		{kdf: `{"name":"argon2id","time":1,"memory":1024,"threads":1}`, valid: true},
+		{kdf: `{"name":"scrypt","n":1024,"r":8,"p":1}`, valid: true},
+		{kdf: `{"name":"argon2id","memory":1024,"threads":1}`},
+		{kdf: `{"name":"argon2id","time":1,"memory":1024}`},
+		{kdf: `{"name":"argon2id","time":1,"memory":31,"threads":4}`},
+		{kdf: `{"name":"argon2id","time":13,"memory":1024,"threads":1}`},
+		{kdf: `{"name":"argon2id","time":1,"memory":262145,"threads":1}`},
+		{kdf: `{"name":"argon2id","time":1,"memory":1024,"threads":17}`},
+		{kdf: `{"name":"scrypt","n":1024,"r":8}`},
+		{kdf: `{"name":"scrypt","n":1024,"p":1}`},
+		{kdf: `{"name":"scrypt","r":8,"p":1}`},
+		{kdf: `{"name":"scrypt","n":1,"r":8,"p":1}`},
+		{kdf: `{"name":"scrypt","n":1000,"r":8,"p":1}`},
+		{kdf: `{"name":"scrypt","n":262144,"r":8,"p":1}`},
+		{kdf: `{"name":"scrypt","n":1024,"r":9,"p":1}`},
+		{kdf: `{"name":"scrypt","n":1024,"r":8,"p":5}`},
+	}
+
+	for _, c := range tests {
+		// the parameters of keystore.json are checked before deriving the key
+		dir := t.TempDir()
+		cfg := `{"version":1,"kdf":` + c.kdf + `,"nonce":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA","check":""}`
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, configFile), []byte(cfg), 0o600))
+		_, err := OpenDir(dir, "passphrase", testScrypt)
+		if c.valid {
+			// the check value does not match the derived key
+			assert.ErrorIs(t, err, subkey.ErrInvalidPassword, c.kdf)
+		} else {
+			assert.ErrorIs(t, err, subkey.ErrInvalidKeystore, c.kdf)
+		}
+	}
+}
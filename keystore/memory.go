@@ -0,0 +1,125 @@
+package keystore
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+type memoryEntry struct {
+	key  Key
+	pair subkey.KeyPair
+}
+
+// Memory is a Keystore that keeps the key pairs in memory.
+type Memory struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+}
+
+var _ Keystore = (*Memory)(nil)
+
+// NewMemory returns an empty in-memory keystore.
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]memoryEntry)}
+}
+
+func (m *Memory) Generate(scheme subkey.Scheme, keyType subkey.KeyTypeID, label string) (Key, error) {
+	if scheme == nil {
+		return Key{}, fmt.Errorf("%w: nil", subkey.ErrUnknownScheme)
+	}
+
+	pair, err := scheme.Generate()
+	if err != nil {
+		return Key{}, err
+	}
+
+	return m.Import(scheme, keyType, label, pair)
+}
+
+func (m *Memory) Import(scheme subkey.Scheme, keyType subkey.KeyTypeID, label string, pair subkey.KeyPair) (Key, error) {
+	if _, err := schemeName(scheme); err != nil {
+		return Key{}, err
+	}
+
+	// keep a private copy restored from the seed
+	seed, err := seedOf(scheme, pair)
+	if err != nil {
+		return Key{}, err
+	}
+
+	pair, err = scheme.FromSeed(seed)
+	if err != nil {
+		return Key{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := Key{Scheme: scheme, Type: keyType, Public: pair.Public(), Label: label}
+	if err := checkNew(m.keys(), key.Public, label); err != nil {
+		return Key{}, err
+	}
+
+	m.entries[string(key.Public)] = memoryEntry{key: key, pair: pair}
+	return key.clone(), nil
+}
+
+func (m *Memory) List() ([]Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := m.keys()
+	sortKeys(keys)
+	return keys, nil
+}
+
+func (m *Memory) Get(public []byte) (Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.entries[string(public)]
+	if !ok {
+		return Key{}, fmt.Errorf("%w: %s", subkey.ErrKeyNotFound, subkey.EncodeHex(public))
+	}
+
+	return e.key.clone(), nil
+}
+
+func (m *Memory) GetByLabel(label string) (Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return findByLabel(m.keys(), label)
+}
+
+func (m *Memory) Sign(public, msg []byte) ([]byte, error) {
+	// the read lock is held while signing so Delete cannot wipe the key pair in use
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.entries[string(public)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", subkey.ErrKeyNotFound, subkey.EncodeHex(public))
+	}
+
+	return e.pair.Sign(msg)
+}
+
+func (m *Memory) Delete(public []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[string(public)]
+	if !ok {
+		return fmt.Errorf("%w: %s", subkey.ErrKeyNotFound, subkey.EncodeHex(public))
+	}
+
+	delete(m.entries, string(public))
+	subkey.Wipe(e.pair)
+	return nil
+}
+
+func (m *Memory) keys() []Key {
+	keys := make([]Key, 0, len(m.entries))
+	for _, e := range m.entries {
+		keys = append(keys, e.key.clone())
+	}
+
+	return keys
+}
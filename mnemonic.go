@@ -0,0 +1,58 @@
+package subkey
+
+import (
+	"fmt"
+
+	"github.com/cosmos/go-bip39"
+)
+
+// mnemonicEntropyBits maps the supported BIP39 phrase lengths to the entropy strength.
+var mnemonicEntropyBits = map[int]int{
+	12: 128,
+	15: 160,
+	18: 192,
+	21: 224,
+	24: 256,
+}
+
+// MnemonicEntropyBits returns the entropy strength in bits for a phrase with the given number of words.
+func MnemonicEntropyBits(words int) (int, error) {
+	bits, ok := mnemonicEntropyBits[words]
+	if !ok {
+		return 0, fmt.Errorf("%w: unsupported length of %d words, expected 12, 15, 18, 21 or 24", ErrInvalidPhrase, words)
+	}
+
+	return bits, nil
+}
+
+// NewMnemonic generates a random BIP39 phrase with the given number of words.
+func NewMnemonic(words int) (string, error) {
+	bits, err := MnemonicEntropyBits(words)
+	if err != nil {
+		return "", err
+	}
+
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// GenerateWithPhrase generates a random BIP39 phrase with the given number of words
+// and returns it along with the KeyPair the scheme derives from it.
+// Calling scheme.FromPhrase(phrase, password) later yields the same KeyPair.
+func GenerateWithPhrase(scheme Scheme, words int, password string) (phrase string, kp KeyPair, err error) {
+	phrase, err = NewMnemonic(words)
+	if err != nil {
+		return "", nil, err
+	}
+
+	kp, err = scheme.FromPhrase(phrase, password)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return phrase, kp, nil
+}
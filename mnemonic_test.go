@@ -0,0 +1,79 @@
+package subkey_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func TestNewMnemonic(t *testing.T) {
+	tests := []struct {
+		words, bits int
+		err         bool
+	}{
+		{words: 12, bits: 128},
+		{words: 15, bits: 160},
+		{words: 18, bits: 192},
+		{words: 21, bits: 224},
+		{words: 24, bits: 256},
+		{words: 13, err: true},
+		{words: 0, err: true},
+	}
+
+	for _, c := range tests {
+		bits, err := subkey.MnemonicEntropyBits(c.words)
+		phrase, perr := subkey.NewMnemonic(c.words)
+		if c.err {
+			assert.ErrorIs(t, err, subkey.ErrInvalidPhrase)
+			assert.ErrorIs(t, perr, subkey.ErrInvalidPhrase)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.NoError(t, perr)
+		assert.Equal(t, c.bits, bits)
+		assert.Len(t, strings.Fields(phrase), c.words)
+		assert.True(t, bip39.IsMnemonicValid(phrase))
+		// the entropy followed by a byte holding the checksum
+		b, err := bip39.MnemonicToByteArray(phrase)
+		assert.NoError(t, err)
+		assert.Len(t, b, c.bits/8+1)
+	}
+}
+
+func TestGenerateWithPhrase(t *testing.T) {
+	// the seed length of every scheme, also the length FromPhrase takes from the BIP39 seed
+	tests := map[subkey.Scheme]int{
+		sr25519.Scheme{}: 32,
+		ed25519.Scheme{}: 32,
+		ecdsa.Scheme{}:   32,
+	}
+
+	for scheme, seedLen := range tests {
+		phrase, kp, err := subkey.GenerateWithPhrase(scheme, 12, "password")
+		assert.NoError(t, err)
+		assert.Len(t, kp.Seed(), seedLen)
+
+		// the phrase is the backup: it restores the key pair, and it is a secret URI as is
+		got, err := scheme.FromPhrase(phrase, "password")
+		assert.NoError(t, err)
+		assert.Equal(t, kp.Seed(), got.Seed())
+		got, err = subkey.DeriveKeyPair(scheme, phrase+"///password")
+		assert.NoError(t, err)
+		assert.Equal(t, kp.Public(), got.Public())
+
+		// the password is part of the key
+		got, err = scheme.FromPhrase(phrase, "")
+		assert.NoError(t, err)
+		assert.NotEqual(t, kp.Public(), got.Public())
+
+		_, _, err = subkey.GenerateWithPhrase(scheme, 13, "")
+		assert.ErrorIs(t, err, subkey.ErrInvalidPhrase)
+	}
+}
@@ -0,0 +1,149 @@
+package multi
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/scale"
+)
+
+// AddressKind is the variant index of MultiAddress.
+type AddressKind uint8
+
+const (
+	// AddressID is the AccountId32 variant.
+	AddressID AddressKind = iota
+	// AddressIndex is the compact encoded account index variant.
+	AddressIndex
+	// AddressRaw is the variable length raw bytes variant.
+	AddressRaw
+	// Address32 is the 32 byte variant.
+	Address32
+	// Address20 is the 20 byte variant, such as an Ethereum H160.
+	Address20
+)
+
+// Address is Substrate's MultiAddress<AccountId32, u32>.
+// Data holds the bytes of the ID, Raw, Address32 and Address20 variants.
+type Address struct {
+	Kind  AddressKind
+	Index uint32
+	Data  []byte
+}
+
+// NewAddressID returns the Id variant for the account.
+func NewAddressID(id subkey.AccountID) Address {
+	return Address{Kind: AddressID, Data: id.Bytes()}
+}
+
+// NewAddressIndex returns the Index variant for the account index.
+func NewAddressIndex(index uint32) Address {
+	return Address{Kind: AddressIndex, Index: index}
+}
+
+// NewAddressRaw returns the Raw variant for the bytes.
+func NewAddressRaw(raw []byte) Address {
+	return Address{Kind: AddressRaw, Data: raw}
+}
+
+// NewAddress32 returns the Address32 variant.
+func NewAddress32(b [32]byte) Address {
+	return Address{Kind: Address32, Data: b[:]}
+}
+
+// NewAddress20 returns the Address20 variant.
+func NewAddress20(b [20]byte) Address {
+	return Address{Kind: Address20, Data: b[:]}
+}
+
+// AccountID returns the account of the Id variant.
+func (a Address) AccountID() (subkey.AccountID, bool) {
+	if a.Kind != AddressID {
+		return subkey.AccountID{}, false
+	}
+
+	id, err := subkey.NewAccountID(a.Data)
+	return id, err == nil
+}
+
+// fixedLength returns the data length of the fixed size variants.
+func (a AddressKind) fixedLength() (int, bool) {
+	switch a {
+	case AddressID, Address32:
+		return 32, true
+	case Address20:
+		return 20, true
+	}
+
+	return 0, false
+}
+
+// Encode encodes the address as the SCALE enum.
+func (a Address) Encode(encoder scale.Encoder) error {
+	if a.Kind > Address20 {
+		return fmt.Errorf("%w: MultiAddress variant %d", subkey.ErrInvalidAddress, a.Kind)
+	}
+
+	if l, ok := a.Kind.fixedLength(); ok && len(a.Data) != l {
+		return &subkey.LengthError{Err: subkey.ErrInvalidAddress, Got: len(a.Data), Want: []int{l}}
+	}
+
+	if err := encoder.PushByte(byte(a.Kind)); err != nil {
+		return err
+	}
+
+	switch a.Kind {
+	case AddressIndex:
+		return encoder.EncodeUintCompact(*new(big.Int).SetUint64(uint64(a.Index)))
+	case AddressRaw:
+		return encoder.Encode(a.Data)
+	default:
+		return encoder.Write(a.Data)
+	}
+}
+
+// Decode decodes the SCALE enum into the address.
+func (a *Address) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	kind := AddressKind(b)
+	switch kind {
+	case AddressIndex:
+		index, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return err
+		}
+
+		if !index.IsUint64() || index.Uint64() > 1<<32-1 {
+			return fmt.Errorf("%w: account index %v overflows u32", subkey.ErrInvalidAddress, index)
+		}
+
+		*a = Address{Kind: kind, Index: uint32(index.Uint64())}
+		return nil
+	case AddressRaw:
+		var raw []byte
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+
+		*a = Address{Kind: kind, Data: raw}
+		return nil
+	}
+
+	l, ok := kind.fixedLength()
+	if !ok {
+		return fmt.Errorf("%w: MultiAddress variant %d", subkey.ErrInvalidAddress, b)
+	}
+
+	data := make([]byte, l)
+	if err := decoder.Read(data); err != nil {
+		return err
+	}
+
+	*a = Address{Kind: kind, Data: data}
+	return nil
+}
@@ -0,0 +1,258 @@
+// Package multi implements Substrate's MultiSigner, MultiSignature and MultiAddress enums.
+package multi
+
+import (
+	"fmt"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/scale"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Kind is the variant index shared by MultiSigner and MultiSignature.
+type Kind uint8
+
+const (
+	// Ed25519 is the ed25519 variant.
+	Ed25519 Kind = iota
+	// Sr25519 is the sr25519 variant.
+	Sr25519
+	// Ecdsa is the ecdsa variant.
+	Ecdsa
+)
+
+// publicKeyLengths and signatureLengths are indexed by Kind.
+var (
+	publicKeyLengths = [...]int{32, 32, 33}
+	signatureLengths = [...]int{64, 64, 65}
+)
+
+// KindOf returns the variant for the scheme.
+func KindOf(scheme subkey.Scheme) (Kind, error) {
+	switch scheme.(type) {
+	case ed25519.Scheme:
+		return Ed25519, nil
+	case sr25519.Scheme:
+		return Sr25519, nil
+	case ecdsa.Scheme:
+		return Ecdsa, nil
+	}
+
+	return 0, fmt.Errorf("%w: %v has no MultiSigner variant", subkey.ErrUnknownScheme, scheme)
+}
+
+// Scheme returns the scheme of the variant.
+func (k Kind) Scheme() (subkey.Scheme, error) {
+	switch k {
+	case Ed25519:
+		return ed25519.Scheme{}, nil
+	case Sr25519:
+		return sr25519.Scheme{}, nil
+	case Ecdsa:
+		return ecdsa.Scheme{}, nil
+	}
+
+	return nil, fmt.Errorf("%w: variant %d", subkey.ErrUnknownScheme, k)
+}
+
+func (k Kind) String() string {
+	switch k {
+	case Ed25519:
+		return "Ed25519"
+	case Sr25519:
+		return "Sr25519"
+	case Ecdsa:
+		return "Ecdsa"
+	}
+
+	return fmt.Sprintf("Kind(%d)", uint8(k))
+}
+
+// Signer is Substrate's MultiSigner.
+type Signer struct {
+	Kind      Kind
+	PublicKey []byte
+}
+
+// NewSigner returns the MultiSigner of the public key under the scheme.
+func NewSigner(scheme subkey.Scheme, pub subkey.PublicKey) (Signer, error) {
+	kind, err := KindOf(scheme)
+	if err != nil {
+		return Signer{}, err
+	}
+
+	s := Signer{Kind: kind, PublicKey: pub.Public()}
+	return s, s.validate()
+}
+
+// AccountID returns the AccountId32 of the signer, as IdentifyAccount does in Substrate.
+// The ecdsa public key is hashed with blake2_256.
+func (s Signer) AccountID() subkey.AccountID {
+	if s.Kind == Ecdsa {
+		return blake2b.Sum256(s.PublicKey)
+	}
+
+	var id subkey.AccountID
+	copy(id[:], s.PublicKey)
+	return id
+}
+
+// Verify verifies the signature of msg made by the signer.
+func (s Signer) Verify(msg []byte, sig Signature) bool {
+	return sig.Verify(msg, s.AccountID())
+}
+
+func (s Signer) validate() error {
+	if int(s.Kind) >= len(publicKeyLengths) {
+		return fmt.Errorf("%w: variant %d", subkey.ErrUnknownScheme, s.Kind)
+	}
+
+	if want := publicKeyLengths[s.Kind]; len(s.PublicKey) != want {
+		return &subkey.LengthError{Err: subkey.ErrInvalidPublicKey, Got: len(s.PublicKey), Want: []int{want}}
+	}
+
+	return nil
+}
+
+// Encode encodes the signer as the SCALE enum.
+func (s Signer) Encode(encoder scale.Encoder) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if err := encoder.PushByte(byte(s.Kind)); err != nil {
+		return err
+	}
+
+	return encoder.Write(s.PublicKey)
+}
+
+// Decode decodes the SCALE enum into the signer.
+func (s *Signer) Decode(decoder scale.Decoder) error {
+	kind, err := decodeKind(decoder)
+	if err != nil {
+		return err
+	}
+
+	pub := make([]byte, publicKeyLengths[kind])
+	if err := decoder.Read(pub); err != nil {
+		return err
+	}
+
+	*s = Signer{Kind: kind, PublicKey: pub}
+	return nil
+}
+
+// Signature is Substrate's MultiSignature.
+type Signature struct {
+	Kind      Kind
+	Signature []byte
+}
+
+// Sign signs msg with the key pair of the scheme and wraps the signature.
+func Sign(scheme subkey.Scheme, pair subkey.KeyPair, msg []byte) (Signature, error) {
+	kind, err := KindOf(scheme)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	sig, err := pair.Sign(msg)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	s := Signature{Kind: kind, Signature: sig}
+	return s, s.validate()
+}
+
+// Verify verifies the signature of msg against the account ID like MultiSignature::verify.
+// Ecdsa signatures are checked by recovering the signer and comparing the blake2_256 of its public key.
+func (s Signature) Verify(msg []byte, accountID subkey.AccountID) bool {
+	if s.validate() != nil {
+		return false
+	}
+
+	if s.Kind == Ecdsa {
+		return verifyEcdsa(msg, accountID, s.Signature)
+	}
+
+	scheme, err := s.Kind.Scheme()
+	if err != nil {
+		return false
+	}
+
+	return scheme.(subkey.AccountVerifier).VerifyAccount(accountID[:], msg, s.Signature)
+}
+
+// verifyEcdsa mirrors sp_io::crypto::secp256k1_ecdsa_recover_compressed as used by MultiSignature::verify.
+// The signer is recovered without a low-S check and the recovery bytes 27 and 28 are taken as 0 and 1.
+func verifyEcdsa(msg []byte, accountID subkey.AccountID, signature []byte) bool {
+	sig := append([]byte{}, signature...)
+	if sig[64] > 26 {
+		sig[64] -= 27
+	}
+
+	pub, err := ecdsa.Recover(msg, sig)
+	if err != nil {
+		return false
+	}
+
+	return subkey.AccountID(blake2b.Sum256(pub.Public())) == accountID
+}
+
+func (s Signature) validate() error {
+	if int(s.Kind) >= len(signatureLengths) {
+		return fmt.Errorf("%w: variant %d", subkey.ErrUnknownScheme, s.Kind)
+	}
+
+	if want := signatureLengths[s.Kind]; len(s.Signature) != want {
+		return &subkey.LengthError{Err: subkey.ErrInvalidSignatureLength, Got: len(s.Signature), Want: []int{want}}
+	}
+
+	return nil
+}
+
+// Encode encodes the signature as the SCALE enum.
+func (s Signature) Encode(encoder scale.Encoder) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if err := encoder.PushByte(byte(s.Kind)); err != nil {
+		return err
+	}
+
+	return encoder.Write(s.Signature)
+}
+
+// Decode decodes the SCALE enum into the signature.
+func (s *Signature) Decode(decoder scale.Decoder) error {
+	kind, err := decodeKind(decoder)
+	if err != nil {
+		return err
+	}
+
+	sig := make([]byte, signatureLengths[kind])
+	if err := decoder.Read(sig); err != nil {
+		return err
+	}
+
+	*s = Signature{Kind: kind, Signature: sig}
+	return nil
+}
+
+func decodeKind(decoder scale.Decoder) (Kind, error) {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return 0, err
+	}
+
+	if int(b) >= len(publicKeyLengths) {
+		return 0, fmt.Errorf("%w: variant %d", subkey.ErrUnknownScheme, b)
+	}
+
+	return Kind(b), nil
+}
@@ -0,0 +1,151 @@
+package multi
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/scale"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func encode(t *testing.T, value interface{}) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, scale.NewEncoder(&buf).Encode(value))
+	return buf.Bytes()
+}
+
+func decode(t *testing.T, b []byte, target interface{}) {
+	assert.NoError(t, scale.NewDecoder(bytes.NewReader(b)).Decode(target))
+}
+
+func TestSignerAndSignature(t *testing.T) {
+	msg := []byte("extrinsic")
+	// the //Alice keys of subkey inspect
+	tests := map[subkey.Scheme]struct {
+		kind         Kind
+		public       string
+		signatureLen int
+	}{
+		ed25519.Scheme{}: {Ed25519, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", 64},
+		sr25519.Scheme{}: {Sr25519, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d", 64},
+		ecdsa.Scheme{}:   {Ecdsa, "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1", 65},
+	}
+
+	for scheme, c := range tests {
+		kp, err := subkey.DeriveKeyPair(scheme, "//Alice")
+		assert.NoError(t, err)
+		public, _ := subkey.DecodeHex(c.public)
+		assert.Equal(t, public, kp.Public())
+
+		signer, err := NewSigner(scheme, kp)
+		assert.NoError(t, err)
+		assert.Equal(t, c.kind, signer.Kind)
+		assert.Equal(t, kp.AccountID(), signer.AccountID().Bytes())
+		assert.Equal(t, append([]byte{byte(c.kind)}, public...), encode(t, signer))
+
+		sig, err := Sign(scheme, kp, msg)
+		assert.NoError(t, err)
+		b := encode(t, sig)
+		assert.Len(t, b, 1+c.signatureLen)
+		assert.Equal(t, byte(c.kind), b[0])
+
+		var gotSigner Signer
+		decode(t, encode(t, signer), &gotSigner)
+		var gotSig Signature
+		decode(t, b, &gotSig)
+		assert.True(t, gotSigner.Verify(msg, gotSig))
+		assert.False(t, gotSig.Verify([]byte("other"), signer.AccountID()))
+
+		bob, err := subkey.DeriveKeyPair(scheme, "//Bob")
+		assert.NoError(t, err)
+		bobSigner, err := NewSigner(scheme, bob)
+		assert.NoError(t, err)
+		assert.False(t, gotSig.Verify(msg, bobSigner.AccountID()))
+	}
+
+	_, err := KindOf(ecdsa.EthereumScheme{})
+	assert.ErrorIs(t, err, subkey.ErrUnknownScheme)
+
+	var sig Signature
+	err = scale.NewDecoder(bytes.NewReader([]byte{3})).Decode(&sig)
+	assert.Error(t, err)
+	assert.False(t, Signature{Kind: Sr25519, Signature: make([]byte, 65)}.Verify(nil, subkey.AccountID{}))
+}
+
+func TestEcdsaSignatureRecovery(t *testing.T) {
+	msg := []byte("extrinsic")
+	kp, err := subkey.DeriveKeyPair(ecdsa.Scheme{}, "//Alice")
+	assert.NoError(t, err)
+	signer, err := NewSigner(ecdsa.Scheme{}, kp)
+	assert.NoError(t, err)
+	sig, err := Sign(ecdsa.Scheme{}, kp, msg)
+	assert.NoError(t, err)
+
+	// sp_io recovers the signer, so the high-S form and Ethereum recovery bytes verify on chain
+	n := secp256k1.S256().Params().N
+	highS := append([]byte{}, sig.Signature...)
+	s := new(big.Int).SetBytes(highS[32:64])
+	s.Sub(n, s).FillBytes(highS[32:64])
+	highS[64] ^= 1
+	ethV := append([]byte{}, sig.Signature...)
+	ethV[64] += 27
+
+	tests := []struct {
+		signature []byte
+		valid     bool
+	}{
+		{sig.Signature, true},
+		{highS, true},
+		{ethV, true},
+		{append(append([]byte{}, sig.Signature[:64]...), sig.Signature[64]^1), false},
+		{append(append([]byte{}, sig.Signature[:64]...), 4), false},
+	}
+
+	for _, c := range tests {
+		got := Signature{Kind: Ecdsa, Signature: c.signature}
+		assert.Equal(t, c.valid, got.Verify(msg, signer.AccountID()))
+		assert.Equal(t, c.valid, signer.Verify(msg, got))
+	}
+}
+
+func TestAddress(t *testing.T) {
+	alice, err := subkey.ParseAccountID("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		addr    Address
+		encoded []byte
+	}{
+		{NewAddressID(alice), append([]byte{0}, alice[:]...)},
+		{NewAddressIndex(1), []byte{1, 4}},
+		{NewAddressIndex(1 << 20), []byte{1, 2, 0, 0x40, 0}},
+		{NewAddressRaw([]byte{1, 2}), []byte{2, 8, 1, 2}},
+		{NewAddress32(alice), append([]byte{3}, alice[:]...)},
+		{NewAddress20([20]byte{1}), append([]byte{4, 1}, make([]byte, 19)...)},
+	}
+
+	for _, tt := range tests {
+		b := encode(t, tt.addr)
+		assert.Equal(t, tt.encoded, b)
+		var got Address
+		decode(t, b, &got)
+		assert.Equal(t, tt.addr, got)
+	}
+
+	id, ok := NewAddressID(alice).AccountID()
+	assert.True(t, ok)
+	assert.Equal(t, alice, id)
+	_, ok = NewAddressIndex(1).AccountID()
+	assert.False(t, ok)
+
+	var buf bytes.Buffer
+	assert.Error(t, scale.NewEncoder(&buf).Encode(Address{Kind: Address20, Data: []byte{1}}))
+	var got Address
+	assert.Error(t, scale.NewDecoder(bytes.NewReader([]byte{5})).Decode(&got))
+}
@@ -0,0 +1,40 @@
+package multi
+
+import (
+	"fmt"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// accountKinds is the order in which the variants are tried against an account.
+var accountKinds = []Kind{Sr25519, Ed25519, Ecdsa}
+
+// VerifyByAddress verifies the signature of msg against the account of the SS58 address
+// and returns the scheme that matched, like Substrate's MultiSignature verification against AccountId32.
+// Only sr25519, ed25519 and ecdsa are tried, whatever schemes are registered.
+func VerifyByAddress(address string, msg, signature []byte) (subkey.Scheme, error) {
+	_, b, err := subkey.SS58Decode(address)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, err := subkey.NewAccountID(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return VerifyByAccountID(accountID, msg, signature)
+}
+
+// VerifyByAccountID verifies the signature of msg against the account ID and returns the scheme that matched.
+// sr25519 and ed25519 signatures are checked against the account ID as the public key,
+// ecdsa signatures by recovering the signer as Signature.Verify does.
+func VerifyByAccountID(accountID subkey.AccountID, msg, signature []byte) (subkey.Scheme, error) {
+	for _, kind := range accountKinds {
+		if (Signature{Kind: kind, Signature: signature}).Verify(msg, accountID) {
+			return kind.Scheme()
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no scheme matched the account", subkey.ErrInvalidSignature)
+}
@@ -0,0 +1,56 @@
+package multi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func TestVerifyByAddress(t *testing.T) {
+	msg := []byte("login")
+	// the substrate addresses of //Alice, as printed by subkey inspect
+	tests := map[subkey.Scheme]string{
+		sr25519.Scheme{}: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+		ed25519.Scheme{}: "5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu",
+		ecdsa.Scheme{}:   "5C7C2Z5sWbytvHpuLTvzKunnnRwQxft1jiqrLD5rhucQ5S9X",
+	}
+
+	for scheme, address := range tests {
+		kp, err := subkey.DeriveKeyPair(scheme, "//Alice")
+		assert.NoError(t, err)
+		sig, err := kp.Sign(msg)
+		assert.NoError(t, err)
+
+		got, err := VerifyByAddress(address, msg, sig)
+		assert.NoError(t, err)
+		assert.Equal(t, scheme, got)
+
+		// the network of the address does not matter
+		got, err = VerifyByAddress(kp.SS58Address(2), msg, sig)
+		assert.NoError(t, err)
+		assert.Equal(t, scheme, got)
+
+		_, err = VerifyByAddress(address, []byte("other"), sig)
+		assert.ErrorIs(t, err, subkey.ErrInvalidSignature)
+
+		bob, err := subkey.DeriveKeyPair(scheme, "//Bob")
+		assert.NoError(t, err)
+		_, err = VerifyByAddress(bob.SS58Address(42), msg, sig)
+		assert.ErrorIs(t, err, subkey.ErrInvalidSignature)
+	}
+
+	_, err := VerifyByAddress("invalid", msg, nil)
+	assert.ErrorIs(t, err, subkey.ErrInvalidAddress)
+
+	// Ethereum signatures are not a MultiSignature variant
+	eth, err := subkey.DeriveKeyPair(ecdsa.EthereumScheme{}, "//Alice")
+	assert.NoError(t, err)
+	sig, err := eth.Sign(msg)
+	assert.NoError(t, err)
+	_, err = VerifyByAddress(eth.SS58Address(42), msg, sig)
+	assert.ErrorIs(t, err, subkey.ErrInvalidSignature)
+}
@@ -0,0 +1,251 @@
+// Package nodekeystore reads and writes the keystore directory of Substrate nodes,
+// the files behind author_insertKey. Each key is a file named hex(KeyTypeID)+hex(public)
+// holding the JSON quoted secret URI of the key.
+package nodekeystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+// mnemonicWords is the length of the phrases of generated keys, as in Substrate.
+const mnemonicWords = 12
+
+// schemes maps the session key types to the schemes used by Substrate and Polkadot nodes.
+var schemes = map[subkey.KeyTypeID]subkey.Scheme{
+	subkey.KeyTypeBabe:               sr25519.Scheme{},
+	subkey.KeyTypeGrandpa:            ed25519.Scheme{},
+	subkey.KeyTypeImOnline:           sr25519.Scheme{},
+	subkey.KeyTypeParachainValidator: sr25519.Scheme{},
+	subkey.KeyTypeAssignment:         sr25519.Scheme{},
+	subkey.KeyTypeAuthorityDiscovery: sr25519.Scheme{},
+	subkey.KeyTypeBeefy:              ecdsa.Scheme{},
+	subkey.KeyTypeAura:               sr25519.Scheme{},
+}
+
+// SchemeOf returns the scheme of the keys of the key type.
+func SchemeOf(keyType subkey.KeyTypeID) (subkey.Scheme, error) {
+	scheme, ok := schemes[keyType]
+	if !ok {
+		return nil, fmt.Errorf("%w: no scheme for %s", subkey.ErrInvalidKeyType, keyType)
+	}
+
+	return scheme, nil
+}
+
+// Key is a public key of the keystore along with its key type.
+type Key struct {
+	Type   subkey.KeyTypeID
+	Public []byte
+}
+
+// Keystore is the keystore directory of a node.
+type Keystore struct {
+	dir      string
+	password string
+}
+
+// Open returns the keystore in dir, creating the directory if needed.
+// The password, if any, is the keystore password of the node and overrides the password of the stored URIs.
+func Open(dir, password string) (*Keystore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &Keystore{dir: dir, password: password}, nil
+}
+
+// Dir returns the keystore directory.
+func (ks *Keystore) Dir() string {
+	return ks.dir
+}
+
+// Insert derives the key pair of the URI with the scheme of the key type and stores the URI,
+// like author_insertKey does after checking the public key.
+func (ks *Keystore) Insert(keyType subkey.KeyTypeID, suri string) (subkey.KeyPair, error) {
+	kp, err := ks.derive(keyType, suri)
+	if err != nil {
+		return nil, err
+	}
+
+	return kp, ks.write(keyType, kp.Public(), suri)
+}
+
+// Generate stores a new key pair of the key type with a 12 word mnemonic phrase.
+func (ks *Keystore) Generate(keyType subkey.KeyTypeID) (subkey.KeyPair, error) {
+	scheme, err := SchemeOf(keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	phrase, kp, err := subkey.GenerateWithPhrase(scheme, mnemonicWords, ks.password)
+	if err != nil {
+		return nil, err
+	}
+
+	return kp, ks.write(keyType, kp.Public(), phrase)
+}
+
+// Load reads the URI of the key and derives its key pair with DeriveKeyPair.
+// ErrKeyNotFound is returned if the keystore has no such key.
+func (ks *Keystore) Load(keyType subkey.KeyTypeID, public []byte) (subkey.KeyPair, error) {
+	suri, err := ks.SecretURI(keyType, public)
+	if err != nil {
+		return nil, err
+	}
+
+	kp, err := ks.derive(keyType, suri)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(kp.Public(), public) {
+		return nil, fmt.Errorf("%w: %s does not derive to its public key", subkey.ErrInvalidKeystore, fileName(keyType, public))
+	}
+
+	return kp, nil
+}
+
+// SecretURI returns the stored URI of the key.
+func (ks *Keystore) SecretURI(keyType subkey.KeyTypeID, public []byte) (string, error) {
+	name := fileName(keyType, public)
+	data, err := os.ReadFile(filepath.Join(ks.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", subkey.ErrKeyNotFound, name)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var suri string
+	if err := json.Unmarshal(data, &suri); err != nil {
+		return "", fmt.Errorf("%w: %s: %v", subkey.ErrInvalidKeystore, name, err)
+	}
+
+	return suri, nil
+}
+
+// Has reports whether the keystore has the key.
+func (ks *Keystore) Has(keyType subkey.KeyTypeID, public []byte) bool {
+	_, err := os.Stat(filepath.Join(ks.dir, fileName(keyType, public)))
+	return err == nil
+}
+
+// HasKeys reports whether the keystore has all the keys, like Keystore::has_keys.
+func (ks *Keystore) HasKeys(keys []Key) bool {
+	for _, k := range keys {
+		if !ks.Has(k.Type, k.Public) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Keys returns the public keys of the key type sorted by file name.
+func (ks *Keystore) Keys(keyType subkey.KeyTypeID) ([][]byte, error) {
+	keys, err := ks.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var pubs [][]byte
+	for _, k := range keys {
+		if k.Type == keyType {
+			pubs = append(pubs, k.Public)
+		}
+	}
+
+	return pubs, nil
+}
+
+// List returns every key of the keystore sorted by file name.
+// Files whose names are not hex(KeyTypeID)+hex(public) are skipped.
+func (ks *Keystore) List() ([]Key, error) {
+	entries, err := os.ReadDir(ks.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []Key
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if k, ok := parseFileName(e.Name()); ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fileName(keys[i].Type, keys[i].Public) < fileName(keys[j].Type, keys[j].Public)
+	})
+	return keys, nil
+}
+
+// Remove deletes the key. ErrKeyNotFound is returned if the keystore has no such key.
+func (ks *Keystore) Remove(keyType subkey.KeyTypeID, public []byte) error {
+	name := fileName(keyType, public)
+	err := os.Remove(filepath.Join(ks.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", subkey.ErrKeyNotFound, name)
+	}
+
+	return err
+}
+
+func (ks *Keystore) derive(keyType subkey.KeyTypeID, suri string) (subkey.KeyPair, error) {
+	scheme, err := SchemeOf(keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := subkey.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	if ks.password != "" {
+		u.Password = ks.password
+	}
+
+	return subkey.DeriveKeyPairFromURI(scheme, u)
+}
+
+func (ks *Keystore) write(keyType subkey.KeyTypeID, public []byte, suri string) error {
+	data, err := json.Marshal(suri)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(ks.dir, fileName(keyType, public)), data, 0o600)
+}
+
+func fileName(keyType subkey.KeyTypeID, public []byte) string {
+	return hex.EncodeToString(keyType[:]) + hex.EncodeToString(public)
+}
+
+func parseFileName(name string) (Key, bool) {
+	b, err := hex.DecodeString(name)
+	if err != nil || len(b) <= len(subkey.KeyTypeID{}) {
+		return Key{}, false
+	}
+
+	var k Key
+	n := copy(k.Type[:], b)
+	k.Public = b[n:]
+	return k, true
+}
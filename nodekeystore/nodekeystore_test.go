@@ -0,0 +1,108 @@
+package nodekeystore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+func fromHex(t *testing.T, hex string) []byte {
+	bytes, success := subkey.DecodeHex(hex)
+	assert.True(t, success)
+	return bytes
+}
+
+func TestInsertLoad(t *testing.T) {
+	ks, err := Open(filepath.Join(t.TempDir(), "keystore"), "")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		keyType subkey.KeyTypeID
+		public  string
+	}{
+		{subkey.KeyTypeBabe, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"},
+		{subkey.KeyTypeGrandpa, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee"},
+		{subkey.KeyTypeBeefy, "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1"},
+	}
+
+	for _, tt := range tests {
+		public := fromHex(t, tt.public)
+		kp, err := ks.Insert(tt.keyType, "//Alice")
+		assert.NoError(t, err)
+		assert.Equal(t, public, kp.Public())
+
+		data, err := os.ReadFile(filepath.Join(ks.Dir(), subkey.EncodeHex(tt.keyType[:])[2:]+tt.public[2:]))
+		assert.NoError(t, err)
+		assert.Equal(t, `"//Alice"`, string(data))
+
+		assert.True(t, ks.Has(tt.keyType, public))
+		got, err := ks.Load(tt.keyType, public)
+		assert.NoError(t, err)
+		assert.Equal(t, kp.Seed(), got.Seed())
+	}
+
+	keys, err := ks.List()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 3)
+	// 62616265 (babe) < 62656566 (beef) < 6772616e (gran)
+	assert.Equal(t, []subkey.KeyTypeID{subkey.KeyTypeBabe, subkey.KeyTypeBeefy, subkey.KeyTypeGrandpa},
+		[]subkey.KeyTypeID{keys[0].Type, keys[1].Type, keys[2].Type})
+	assert.True(t, ks.HasKeys(keys))
+
+	pubs, err := ks.Keys(subkey.KeyTypeGrandpa)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{fromHex(t, tests[1].public)}, pubs)
+
+	assert.NoError(t, ks.Remove(subkey.KeyTypeGrandpa, pubs[0]))
+	assert.False(t, ks.Has(subkey.KeyTypeGrandpa, pubs[0]))
+	assert.False(t, ks.HasKeys(keys))
+	assert.ErrorIs(t, ks.Remove(subkey.KeyTypeGrandpa, pubs[0]), subkey.ErrKeyNotFound)
+	_, err = ks.Load(subkey.KeyTypeGrandpa, pubs[0])
+	assert.ErrorIs(t, err, subkey.ErrKeyNotFound)
+}
+
+func TestGenerate(t *testing.T) {
+	ks, err := Open(t.TempDir(), "secret")
+	assert.NoError(t, err)
+
+	kp, err := ks.Generate(subkey.KeyTypeImOnline)
+	assert.NoError(t, err)
+	suri, err := ks.SecretURI(subkey.KeyTypeImOnline, kp.Public())
+	assert.NoError(t, err)
+	u, err := subkey.ParseSecretURI(suri)
+	assert.NoError(t, err)
+	assert.Empty(t, u.Junctions)
+
+	// the keystore password is applied when loading
+	got, err := ks.Load(subkey.KeyTypeImOnline, kp.Public())
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Public(), got.Public())
+
+	other, err := Open(ks.Dir(), "")
+	assert.NoError(t, err)
+	_, err = other.Load(subkey.KeyTypeImOnline, kp.Public())
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+}
+
+func TestListSkipsForeignFiles(t *testing.T) {
+	ks, err := Open(t.TempDir(), "")
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(ks.Dir(), "README"), nil, 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(ks.Dir(), "62616265"), nil, 0o600))
+	assert.NoError(t, os.Mkdir(filepath.Join(ks.Dir(), "6261626501"), 0o700))
+	keys, err := ks.List()
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = ks.Insert(subkey.KeyTypeID{'a', 'c', 'm', 'e'}, "//Alice")
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeyType)
+
+	public := fromHex(t, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	assert.NoError(t, os.WriteFile(filepath.Join(ks.Dir(), fileName(subkey.KeyTypeBabe, public)), []byte("//Alice"), 0o600))
+	_, err = ks.Load(subkey.KeyTypeBabe, public)
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+}
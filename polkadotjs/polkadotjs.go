@@ -0,0 +1,425 @@
+// Package polkadotjs imports and exports the encrypted key files of polkadot.js
+// and compatible wallets such as Talisman.
+package polkadotjs
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	contentPKCS8 = "pkcs8"
+
+	typeScrypt = "scrypt"
+	typeXSalsa = "xsalsa20-poly1305"
+	typeNone   = "none"
+
+	version = "3"
+
+	saltLength         = 32
+	scryptParamsLength = saltLength + 12
+	nonceLength        = 24
+	keyLength          = 32
+	seedLength         = 32
+	secretKeyLength    = 64
+)
+
+var (
+	pkcs8Header  = []byte{48, 83, 2, 1, 1, 48, 5, 6, 3, 43, 101, 112, 4, 34, 4, 32}
+	pkcs8Divider = []byte{161, 35, 3, 33, 0}
+)
+
+// ScryptParams are the scrypt parameters of an encrypted key file.
+type ScryptParams struct {
+	N, R, P int
+}
+
+// DefaultScryptParams are the parameters used by polkadot.js, and the only ones it accepts.
+var DefaultScryptParams = ScryptParams{N: 1 << 15, R: 8, P: 1}
+
+// validate returns an error unless N is a power of two above 1 and R and P are at least 1.
+// Each parameter is also capped at DefaultScryptParams, so a key file never costs more
+// to open than one written by polkadot.js.
+func (p ScryptParams) validate() error {
+	if p.N <= 1 || p.N&(p.N-1) != 0 || p.R < 1 || p.P < 1 ||
+		p.N > DefaultScryptParams.N || p.R > DefaultScryptParams.R || p.P > DefaultScryptParams.P {
+		return fmt.Errorf("%w: unsupported scrypt parameters %+v", subkey.ErrInvalidKeystore, p)
+	}
+
+	return nil
+}
+
+// Encoding describes how the key of a KeyJSON is encoded.
+type Encoding struct {
+	// Content is the key format followed by the key type, such as ["pkcs8", "sr25519"].
+	Content []string `json:"content"`
+	// Type lists the encryption steps, ["scrypt", "xsalsa20-poly1305"] or ["none"].
+	Type []string `json:"type"`
+	// Version is "3" for base64 encoded keys and lower for hex encoded ones.
+	Version string `json:"version"`
+}
+
+// KeyJSON is a key file exported by polkadot.js.
+type KeyJSON struct {
+	// Encoded is the encrypted PKCS8 key.
+	Encoded  string   `json:"encoded"`
+	Encoding Encoding `json:"encoding"`
+	// Address is the SS58 address of the key, or the hex H160 address for ethereum keys.
+	Address string `json:"address"`
+	// Meta holds the wallet metadata such as name, genesisHash and whenCreated.
+	Meta map[string]interface{} `json:"meta"`
+}
+
+// Import decrypts the polkadot.js key file with the password
+// and returns the key pair along with its scheme.
+func Import(data []byte, password string) (subkey.Scheme, subkey.KeyPair, error) {
+	var k KeyJSON
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	kp, err := k.Decrypt(password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheme, err := k.Scheme()
+	return scheme, kp, err
+}
+
+// Export encrypts the key pair of the scheme with the password and returns the polkadot.js key file.
+// The address is encoded with the SS58 network.
+func Export(scheme subkey.Scheme, pair subkey.KeyPair, password string, network uint16) ([]byte, error) {
+	k, err := Encrypt(scheme, pair, password, network)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(k)
+}
+
+// Scheme returns the scheme of the key type in the encoding content.
+func (k *KeyJSON) Scheme() (subkey.Scheme, error) {
+	if len(k.Encoding.Content) < 2 || k.Encoding.Content[0] != contentPKCS8 {
+		return nil, fmt.Errorf("%w: unsupported content %v", subkey.ErrInvalidKeystore, k.Encoding.Content)
+	}
+
+	switch k.Encoding.Content[1] {
+	case "sr25519":
+		return sr25519.Scheme{}, nil
+	case "ed25519":
+		return ed25519.Scheme{}, nil
+	case "ecdsa":
+		return ecdsa.Scheme{}, nil
+	case "ethereum":
+		return ecdsa.EthereumScheme{}, nil
+	}
+
+	return nil, fmt.Errorf("%w: key type %s", subkey.ErrUnknownScheme, k.Encoding.Content[1])
+}
+
+// Decrypt decrypts the key with the password and checks it against the address.
+// The password is ignored for unencrypted keys.
+func (k *KeyJSON) Decrypt(password string) (subkey.KeyPair, error) {
+	scheme, err := k.Scheme()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := decodeEncoded(k.Encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := decryptData(data, password, k.Encoding.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, pub, err := decodePKCS8(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	kp, err := fromSecret(scheme, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pub) == len(kp.Public()) && !bytes.Equal(pub, kp.Public()) {
+		return nil, fmt.Errorf("%w: public key does not match the secret", subkey.ErrInvalidKeystore)
+	}
+
+	if k.Address != "" && !matchesAddress(kp, k.Address) {
+		return nil, fmt.Errorf("%w: address does not match the key", subkey.ErrInvalidKeystore)
+	}
+
+	return kp, nil
+}
+
+// Encrypt returns the polkadot.js key file of the key pair encrypted with the password.
+// An empty password exports the key unencrypted, like polkadot.js does.
+// The address is encoded with the SS58 network and Meta holds whenCreated.
+func Encrypt(scheme subkey.Scheme, pair subkey.KeyPair, password string, network uint16) (*KeyJSON, error) {
+	return EncryptWithParams(scheme, pair, password, network, DefaultScryptParams)
+}
+
+// EncryptWithParams is Encrypt with custom scrypt parameters, at most DefaultScryptParams.
+// polkadot.js only imports files made with DefaultScryptParams.
+func EncryptWithParams(scheme subkey.Scheme, pair subkey.KeyPair, password string, network uint16, params ScryptParams) (*KeyJSON, error) {
+	kt, err := keyType(scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secretOf(scheme, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	plain := make([]byte, 0, len(pkcs8Header)+len(secret)+len(pkcs8Divider)+len(pair.Public()))
+	plain = append(plain, pkcs8Header...)
+	plain = append(plain, secret...)
+	plain = append(plain, pkcs8Divider...)
+	plain = append(plain, pair.Public()...)
+	defer subkey.Zero(plain)
+	subkey.Zero(secret)
+
+	types := []string{typeNone}
+	encoded := plain
+	if password != "" {
+		types = []string{typeScrypt, typeXSalsa}
+		if encoded, err = encryptData(plain, password, params); err != nil {
+			return nil, err
+		}
+	}
+
+	return &KeyJSON{
+		Encoded: base64.StdEncoding.EncodeToString(encoded),
+		Encoding: Encoding{
+			Content: []string{contentPKCS8, kt},
+			Type:    types,
+			Version: version,
+		},
+		Address: address(pair, network),
+		Meta:    map[string]interface{}{"whenCreated": time.Now().UnixMilli()},
+	}, nil
+}
+
+func keyType(scheme subkey.Scheme) (string, error) {
+	switch scheme.(type) {
+	case sr25519.Scheme:
+		return "sr25519", nil
+	case ed25519.Scheme:
+		return "ed25519", nil
+	case ecdsa.Scheme:
+		return "ecdsa", nil
+	case ecdsa.EthereumScheme:
+		return "ethereum", nil
+	}
+
+	return "", fmt.Errorf("%w: %v is not supported by polkadot.js", subkey.ErrUnknownScheme, scheme)
+}
+
+// secretOf returns the secret key in the polkadot.js form of the scheme.
+func secretOf(scheme subkey.Scheme, pair subkey.KeyPair) ([]byte, error) {
+	if _, ok := scheme.(sr25519.Scheme); ok {
+		return sr25519.Ed25519Bytes(pair)
+	}
+
+	seed := pair.Seed()
+	if len(seed) != seedLength {
+		return nil, subkey.ErrMissingSecret
+	}
+
+	if _, ok := scheme.(ed25519.Scheme); ok {
+		// tweetnacl secret keys are the seed followed by the public key
+		return append(append([]byte{}, seed...), pair.Public()...), nil
+	}
+
+	return seed, nil
+}
+
+// fromSecret returns the key pair of the secret key in the polkadot.js form.
+// 32 byte secrets are seeds, as in polkadot.js.
+func fromSecret(scheme subkey.Scheme, secret []byte) (subkey.KeyPair, error) {
+	if len(secret) == seedLength {
+		return scheme.FromSeed(secret)
+	}
+
+	switch scheme.(type) {
+	case sr25519.Scheme:
+		return sr25519.FromEd25519Bytes(secret)
+	case ed25519.Scheme:
+		return scheme.FromSeed(secret[:seedLength])
+	}
+
+	return nil, &subkey.LengthError{Err: subkey.ErrInvalidSeedLength, Got: len(secret), Want: []int{seedLength}}
+}
+
+// address returns the H160 address of ethereum key pairs and the SS58 address of the others, like polkadot.js.
+func address(kp subkey.KeyPair, network uint16) string {
+	if eth, ok := kp.(ecdsa.EthereumKeyPair); ok {
+		return eth.EthereumAddress()
+	}
+
+	return kp.SS58Address(network)
+}
+
+func matchesAddress(kp subkey.KeyPair, address string) bool {
+	if eth, ok := kp.(ecdsa.EthereumKeyPair); ok {
+		return strings.EqualFold(address, eth.EthereumAddress())
+	}
+
+	_, accountID, err := subkey.SS58Decode(address)
+	return err == nil && bytes.Equal(accountID, kp.AccountID())
+}
+
+// decodeEncoded decodes the hex encoding of old key files or the base64 encoding of version 3.
+func decodeEncoded(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "0x") {
+		if b, ok := subkey.DecodeHex(s); ok {
+			return b, nil
+		}
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+	}
+
+	return b, nil
+}
+
+// decodePKCS8 splits the PKCS8 body into the secret and public key.
+// Like polkadot.js, a 64 byte secret is tried before a 32 byte one.
+func decodePKCS8(b []byte) (secret, pub []byte, err error) {
+	if !bytes.HasPrefix(b, pkcs8Header) {
+		return nil, nil, fmt.Errorf("%w: invalid PKCS8 header", subkey.ErrInvalidKeystore)
+	}
+
+	body := b[len(pkcs8Header):]
+	for _, n := range []int{secretKeyLength, seedLength} {
+		if len(body) >= n+len(pkcs8Divider) && bytes.Equal(body[n:n+len(pkcs8Divider)], pkcs8Divider) {
+			return body[:n], body[n+len(pkcs8Divider):], nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: invalid PKCS8 divider", subkey.ErrInvalidKeystore)
+}
+
+func decryptData(data []byte, password string, types []string) ([]byte, error) {
+	var useScrypt, useXSalsa bool
+	for _, t := range types {
+		switch t {
+		case typeScrypt:
+			useScrypt = true
+		case typeXSalsa:
+			useXSalsa = true
+		case typeNone:
+		default:
+			return nil, fmt.Errorf("%w: unsupported encryption %s", subkey.ErrInvalidKeystore, t)
+		}
+	}
+
+	if !useXSalsa {
+		return data, nil
+	}
+
+	if password == "" {
+		return nil, fmt.Errorf("%w: password required", subkey.ErrInvalidPassword)
+	}
+
+	key := []byte(password)
+	if useScrypt {
+		if len(data) < scryptParamsLength {
+			return nil, fmt.Errorf("%w: missing scrypt parameters", subkey.ErrInvalidKeystore)
+		}
+
+		salt, params := decodeScryptParams(data[:scryptParamsLength])
+		if err := params.validate(); err != nil {
+			return nil, err
+		}
+
+		var err error
+		key, err = scrypt.Key(key, salt, params.N, params.R, params.P, 2*keyLength)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidKeystore, err)
+		}
+
+		data = data[scryptParamsLength:]
+	}
+
+	if len(data) < nonceLength+secretbox.Overhead {
+		return nil, fmt.Errorf("%w: encrypted data too short", subkey.ErrInvalidKeystore)
+	}
+
+	// the key is truncated or zero padded to 32 bytes
+	var k [keyLength]byte
+	var nonce [nonceLength]byte
+	copy(k[:], key)
+	copy(nonce[:], data)
+	plain, ok := secretbox.Open(nil, data[nonceLength:], &nonce, &k)
+	if !ok {
+		return nil, subkey.ErrInvalidPassword
+	}
+
+	return plain, nil
+}
+
+func encryptData(plain []byte, password string, params ScryptParams) ([]byte, error) {
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, saltLength)
+	var nonce [nonceLength]byte
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, params.N, params.R, params.P, 2*keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	var k [keyLength]byte
+	copy(k[:], key)
+	out := encodeScryptParams(salt, params)
+	out = append(out, nonce[:]...)
+	return secretbox.Seal(out, plain, &nonce, &k), nil
+}
+
+// encodeScryptParams returns the salt followed by N, p and r as little endian uint32s.
+func encodeScryptParams(salt []byte, params ScryptParams) []byte {
+	b := make([]byte, scryptParamsLength)
+	copy(b, salt)
+	binary.LittleEndian.PutUint32(b[saltLength:], uint32(params.N))
+	binary.LittleEndian.PutUint32(b[saltLength+4:], uint32(params.P))
+	binary.LittleEndian.PutUint32(b[saltLength+8:], uint32(params.R))
+	return b
+}
+
+func decodeScryptParams(b []byte) ([]byte, ScryptParams) {
+	return b[:saltLength], ScryptParams{
+		N: int(binary.LittleEndian.Uint32(b[saltLength:])),
+		P: int(binary.LittleEndian.Uint32(b[saltLength+4:])),
+		R: int(binary.LittleEndian.Uint32(b[saltLength+8:])),
+	}
+}
@@ -0,0 +1,209 @@
+package polkadotjs
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+func TestExportImport(t *testing.T) {
+	// the content type polkadot.js writes for every scheme, and the length of the secret in the PKCS8 body
+	tests := map[subkey.Scheme]struct {
+		keyType      string
+		secretLength int
+	}{
+		sr25519.Scheme{}:       {"sr25519", secretKeyLength},
+		ed25519.Scheme{}:       {"ed25519", secretKeyLength},
+		ecdsa.Scheme{}:         {"ecdsa", seedLength},
+		ecdsa.EthereumScheme{}: {"ethereum", seedLength},
+	}
+
+	for scheme, c := range tests {
+		t.Run(scheme.String(), func(t *testing.T) {
+			kp, err := subkey.DeriveKeyPair(scheme, "//Alice")
+			assert.NoError(t, err)
+
+			data, err := Export(scheme, kp, "password", 0)
+			assert.NoError(t, err)
+
+			var k KeyJSON
+			assert.NoError(t, json.Unmarshal(data, &k))
+			assert.Equal(t, []string{"pkcs8", c.keyType}, k.Encoding.Content)
+			assert.Equal(t, []string{"scrypt", "xsalsa20-poly1305"}, k.Encoding.Type)
+			assert.Equal(t, "3", k.Encoding.Version)
+			assert.Equal(t, address(kp, 0), k.Address)
+			assert.Contains(t, k.Meta, "whenCreated")
+
+			encoded, err := decodeEncoded(k.Encoded)
+			assert.NoError(t, err)
+			plain, err := decryptData(encoded, "password", k.Encoding.Type)
+			assert.NoError(t, err)
+			secret, pub, err := decodePKCS8(plain)
+			assert.NoError(t, err)
+			assert.Len(t, secret, c.secretLength)
+			assert.Equal(t, kp.Public(), pub)
+
+			gotScheme, got, err := Import(data, "password")
+			assert.NoError(t, err)
+			assert.Equal(t, scheme, gotScheme)
+			assert.Equal(t, kp.Public(), got.Public())
+			assert.Equal(t, kp.AccountID(), got.AccountID())
+
+			sig, err := got.Sign([]byte("msg"))
+			assert.NoError(t, err)
+			assert.True(t, kp.Verify([]byte("msg"), sig))
+
+			_, _, err = Import(data, "wrong")
+			assert.ErrorIs(t, err, subkey.ErrInvalidPassword)
+			_, _, err = Import(data, "")
+			assert.ErrorIs(t, err, subkey.ErrInvalidPassword)
+		})
+	}
+}
+
+func TestScryptParams(t *testing.T) {
+	kp, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Alice")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		params ScryptParams
+		valid  bool
+	}{
+		{params: DefaultScryptParams, valid: true},
+		{params: ScryptParams{N: 1 << 10, R: 8, P: 1}, valid: true},
+		{params: ScryptParams{N: 2, R: 1, P: 1}, valid: true},
+		{params: ScryptParams{N: 0, R: 8, P: 1}},
+		{params: ScryptParams{N: 1, R: 8, P: 1}},
+		{params: ScryptParams{N: 1000, R: 8, P: 1}},
+		{params: ScryptParams{N: 1 << 15, R: 0, P: 1}},
+		{params: ScryptParams{N: 1 << 15, R: 8, P: 0}},
+		{params: ScryptParams{N: 1 << 16, R: 8, P: 1}},
+		{params: ScryptParams{N: 1 << 30, R: 8, P: 1}},
+		{params: ScryptParams{N: 1 << 15, R: 9, P: 1}},
+		{params: ScryptParams{N: 1 << 15, R: 8, P: 2}},
+	}
+
+	for _, c := range tests {
+		_, err := EncryptWithParams(sr25519.Scheme{}, kp, "password", 42, c.params)
+		if c.valid {
+			assert.NoError(t, err, "%+v", c.params)
+		} else {
+			assert.ErrorIs(t, err, subkey.ErrInvalidKeystore, "%+v", c.params)
+		}
+
+		// files with the parameters are rejected before deriving the key, which would panic for zero r or p
+		k, err := Encrypt(sr25519.Scheme{}, kp, "password", 42)
+		assert.NoError(t, err)
+		encoded, err := decodeEncoded(k.Encoded)
+		assert.NoError(t, err)
+		copy(encoded, encodeScryptParams(encoded[:saltLength], c.params))
+		k.Encoded = subkey.EncodeHex(encoded)
+		_, err = k.Decrypt("password")
+		switch {
+		case c.params == DefaultScryptParams:
+			assert.NoError(t, err)
+		case c.valid:
+			// the key derived with other parameters does not open the box
+			assert.ErrorIs(t, err, subkey.ErrInvalidPassword, "%+v", c.params)
+		default:
+			assert.ErrorIs(t, err, subkey.ErrInvalidKeystore, "%+v", c.params)
+		}
+	}
+}
+
+func TestExportUnencrypted(t *testing.T) {
+	kp, err := subkey.DeriveKeyPair(ed25519.Scheme{}, "//Bob")
+	assert.NoError(t, err)
+
+	k, err := Encrypt(ed25519.Scheme{}, kp, "", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"none"}, k.Encoding.Type)
+
+	got, err := k.Decrypt("ignored")
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Seed(), got.Seed())
+}
+
+func TestDecryptLegacy(t *testing.T) {
+	kp, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Alice")
+	assert.NoError(t, err)
+	secret, err := sr25519.Ed25519Bytes(kp)
+	assert.NoError(t, err)
+
+	// versions before 3 hex encode the key and use the zero padded password as the key
+	plain := append(append(append(append([]byte{}, pkcs8Header...), secret...), pkcs8Divider...), kp.Public()...)
+	var key [keyLength]byte
+	var nonce [nonceLength]byte
+	copy(key[:], "password")
+	nonce[0] = 1
+	encrypted := secretbox.Seal(nonce[:], plain, &nonce, &key)
+
+	k := KeyJSON{
+		Encoded: subkey.EncodeHex(encrypted),
+		Encoding: Encoding{
+			Content: []string{"pkcs8", "sr25519"},
+			Type:    []string{"xsalsa20-poly1305"},
+			Version: "2",
+		},
+		Address: kp.SS58Address(2),
+	}
+	got, err := k.Decrypt("password")
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Public(), got.Public())
+}
+
+func TestDecryptErrors(t *testing.T) {
+	alice, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Alice")
+	assert.NoError(t, err)
+	bob, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Bob")
+	assert.NoError(t, err)
+
+	k, err := Encrypt(sr25519.Scheme{}, alice, "", 42)
+	assert.NoError(t, err)
+	k.Address = bob.SS58Address(42)
+	_, err = k.Decrypt("")
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+
+	k, err = Encrypt(sr25519.Scheme{}, alice, "", 42)
+	assert.NoError(t, err)
+	k.Encoding.Content = []string{"pkcs8", "bls12-381"}
+	_, err = k.Decrypt("")
+	assert.ErrorIs(t, err, subkey.ErrUnknownScheme)
+
+	k.Encoding.Content = []string{"pkcs8", "sr25519"}
+	k.Encoding.Type = []string{"aes"}
+	_, err = k.Decrypt("")
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+
+	k.Encoding.Type = []string{"none"}
+	k.Encoded = "AAAA"
+	_, err = k.Decrypt("")
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+
+	_, _, err = Import([]byte("{"), "")
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+}
+
+func TestDecodePKCS8(t *testing.T) {
+	for _, n := range []int{seedLength, secretKeyLength} {
+		secret := make([]byte, n)
+		secret[0] = 7
+		pub := []byte{1, 2, 3}
+		b := append(append(append(append([]byte{}, pkcs8Header...), secret...), pkcs8Divider...), pub...)
+		gotSecret, gotPub, err := decodePKCS8(b)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, gotSecret)
+		assert.Equal(t, pub, gotPub)
+	}
+
+	_, _, err := decodePKCS8(pkcs8Divider)
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+	_, _, err = decodePKCS8(append(append([]byte{}, pkcs8Header...), make([]byte, 40)...))
+	assert.ErrorIs(t, err, subkey.ErrInvalidKeystore)
+}
@@ -0,0 +1,50 @@
+package subkey
+
+import "log/slog"
+
+// Redacted is printed in place of secrets.
+const Redacted = "REDACTED"
+
+// Wiper is implemented by key pairs that can zero their secret material.
+type Wiper interface {
+	// Wipe zeroes the seed and secret key. The key pair can no longer sign or return its seed.
+	Wipe()
+}
+
+// Wipe zeroes the secret material of the key pair if it implements Wiper.
+// Copies of a key pair share its secret, so they are wiped as well.
+func Wipe(pair KeyPair) {
+	if w, ok := pair.(Wiper); ok {
+		w.Wipe()
+	}
+}
+
+// Zero overwrites b with zeros.
+func Zero(b []byte) {
+	clear(b)
+}
+
+// RedactedString describes a key pair of the scheme without its secret,
+// such as Sr25519{public: 0x..., secret: REDACTED}.
+// It backs the fmt.Formatter and fmt.GoStringer implementations of the key pairs.
+func RedactedString(scheme string, public []byte, hasSecret bool) string {
+	s := scheme + "{public: " + EncodeHex(public)
+	if hasSecret {
+		s += ", secret: " + Redacted
+	}
+
+	return s + "}"
+}
+
+// RedactedLogValue is the slog.LogValuer counterpart of RedactedString.
+func RedactedLogValue(scheme string, public []byte, hasSecret bool) slog.Value {
+	attrs := []slog.Attr{
+		slog.String("scheme", scheme),
+		slog.String("public", EncodeHex(public)),
+	}
+	if hasSecret {
+		attrs = append(attrs, slog.String("secret", Redacted))
+	}
+
+	return slog.GroupValue(attrs...)
+}
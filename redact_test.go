@@ -0,0 +1,98 @@
+package subkey_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func TestRedactKeyPair(t *testing.T) {
+	// the string of the //Alice key pair of every scheme, Alith for Ethereum
+	tests := map[subkey.Scheme]struct{ uri, want string }{
+		sr25519.Scheme{}: {"//Alice", "Sr25519{public: 0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d, secret: REDACTED}"},
+		ed25519.Scheme{}: {"//Alice", "Ed25519{public: 0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee, secret: REDACTED}"},
+		ecdsa.Scheme{}:   {"//Alice", "Ecdsa{public: 0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1, secret: REDACTED}"},
+		ecdsa.EthereumScheme{}: {
+			"0x5fb92d6e98884f76de468fa3f6278f8807c48bebc13595d45af5bdc4da702133",
+			"Ethereum{public: 0x02509540919faacf9ab52146c9aa40db68172d83777250b28e4679176e49ccdd9f, secret: REDACTED}",
+		},
+	}
+
+	for scheme, c := range tests {
+		t.Run(scheme.String(), func(t *testing.T) {
+			kp, err := subkey.DeriveKeyPair(scheme, c.uri)
+			assert.NoError(t, err)
+			seed := strings.TrimPrefix(subkey.EncodeHex(kp.Seed()), "0x")
+
+			for _, format := range []string{"%v", "%+v", "%#v", "%s", "%x", "%q"} {
+				for _, v := range []interface{}{kp, &kp, []subkey.KeyPair{kp}, struct{ Pair subkey.KeyPair }{kp}} {
+					out := fmt.Sprintf(format, v)
+					assert.NotContains(t, strings.ToLower(out), seed, format)
+				}
+
+				assert.Equal(t, c.want, fmt.Sprintf(format, kp), format)
+			}
+
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+			logger.Info("signing", "pair", kp)
+			logger.Info("signing", slog.Any("pair", kp))
+			assert.NotContains(t, buf.String(), seed)
+			assert.Contains(t, buf.String(), `"secret":"REDACTED"`)
+
+			pub, err := scheme.FromPublicKey(kp.Public())
+			assert.NoError(t, err)
+			assert.NotContains(t, fmt.Sprint(pub), "secret")
+		})
+	}
+}
+
+func TestWipe(t *testing.T) {
+	// the length of the seed that Wipe zeroes, the mini secret key for sr25519
+	tests := map[subkey.Scheme]int{
+		sr25519.Scheme{}:       32,
+		ed25519.Scheme{}:       32,
+		ecdsa.Scheme{}:         32,
+		ecdsa.EthereumScheme{}: 32,
+	}
+
+	for scheme, seedLen := range tests {
+		t.Run(scheme.String(), func(t *testing.T) {
+			kp, err := subkey.DeriveKeyPair(scheme, "//Alice")
+			assert.NoError(t, err)
+			public := kp.Public()
+			seed := kp.Seed()
+			assert.Len(t, seed, seedLen)
+			copied, err := scheme.FromSeed(seed)
+			assert.NoError(t, err)
+
+			assert.Implements(t, (*subkey.Wiper)(nil), kp)
+			assert.Implements(t, (*io.Closer)(nil), kp)
+			assert.NoError(t, kp.(io.Closer).Close())
+
+			assert.Nil(t, kp.Seed())
+			_, err = kp.Sign([]byte("msg"))
+			assert.ErrorIs(t, err, subkey.ErrMissingSecret)
+			_, err = scheme.Derive(kp, nil)
+			assert.ErrorIs(t, err, subkey.ErrMissingSecret)
+			assert.Equal(t, public, kp.Public())
+			assert.NotContains(t, fmt.Sprint(kp), "secret")
+
+			// key pairs made from the same seed keep their own secret
+			assert.NotEqual(t, make([]byte, len(seed)), seed)
+			sig, err := copied.Sign([]byte("msg"))
+			assert.NoError(t, err)
+			assert.True(t, kp.Verify([]byte("msg"), sig))
+			subkey.Wipe(copied)
+		})
+	}
+}
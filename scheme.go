@@ -16,21 +16,26 @@ type Scheme interface {
 
 // DeriveKeyPair derives the Keypair from the URI using the provided cryptography scheme.
 func DeriveKeyPair(scheme Scheme, uri string) (kp KeyPair, err error) {
-	phrase, path, pwd, err := splitURI(uri)
+	u, err := ParseSecretURI(uri)
 	if err != nil {
 		return nil, err
 	}
 
-	if b, ok := DecodeHex(phrase); ok {
+	return DeriveKeyPairFromURI(scheme, u)
+}
+
+// DeriveKeyPairFromURI derives the Keypair from the parsed URI using the provided cryptography scheme.
+func DeriveKeyPairFromURI(scheme Scheme, u SecretURI) (kp KeyPair, err error) {
+	if b, ok := u.HexSeed(); ok {
 		kp, err = scheme.FromSeed(b)
 	} else {
-		kp, err = scheme.FromPhrase(phrase, pwd)
+		kp, err = scheme.FromPhrase(u.PhraseOrDev(), u.Password)
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	djs, err := deriveJunctions(derivePath(path))
+	djs, err := u.DeriveJunctions()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,73 @@
+package subkey
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	schemesMu sync.RWMutex
+	schemes   = make(map[string]Scheme)
+)
+
+// RegisterScheme makes the scheme available by name through SchemeByName.
+// The name is the lower cased scheme.String(). The scheme packages of this module register
+// themselves when imported, so a blank import such as
+//
+//	import _ "github.com/vedhavyas/go-subkey/v2/sr25519"
+//
+// is enough to select them by name.
+// Like database/sql.Register, it panics if the scheme is nil or the name is already registered.
+func RegisterScheme(scheme Scheme) {
+	if scheme == nil {
+		panic("subkey: RegisterScheme scheme is nil")
+	}
+
+	name := strings.ToLower(scheme.String())
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	if _, dup := schemes[name]; dup {
+		panic("subkey: RegisterScheme called twice for scheme " + name)
+	}
+
+	schemes[name] = scheme
+}
+
+// unregisterScheme removes the scheme with the name so tests can undo RegisterScheme.
+func unregisterScheme(name string) {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	delete(schemes, strings.ToLower(name))
+}
+
+// SchemeByName returns the registered scheme with the name. The lookup is case-insensitive.
+func SchemeByName(name string) (Scheme, error) {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	scheme, ok := schemes[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScheme, name)
+	}
+
+	return scheme, nil
+}
+
+// Schemes returns the registered schemes sorted by name.
+func Schemes() []Scheme {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]Scheme, len(names))
+	for i, name := range names {
+		list[i] = schemes[name]
+	}
+
+	return list
+}
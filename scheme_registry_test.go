@@ -0,0 +1,48 @@
+package subkey_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+type customScheme struct {
+	sr25519.Scheme
+}
+
+func (customScheme) String() string {
+	return "Custom"
+}
+
+func TestSchemeRegistry(t *testing.T) {
+	for name, want := range map[string]subkey.Scheme{
+		"sr25519":  sr25519.Scheme{},
+		"Ed25519":  ed25519.Scheme{},
+		"ECDSA":    ecdsa.Scheme{},
+		"ethereum": ecdsa.EthereumScheme{},
+	} {
+		got, err := subkey.SchemeByName(name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	_, err := subkey.SchemeByName("custom")
+	assert.ErrorIs(t, err, subkey.ErrUnknownScheme)
+
+	subkey.RegisterScheme(customScheme{})
+	t.Cleanup(func() { subkey.UnregisterScheme("custom") })
+	got, err := subkey.SchemeByName("custom")
+	assert.NoError(t, err)
+	assert.Equal(t, customScheme{}, got)
+	assert.Panics(t, func() { subkey.RegisterScheme(customScheme{}) })
+
+	var names []string
+	for _, s := range subkey.Schemes() {
+		names = append(names, s.String())
+	}
+	assert.Equal(t, []string{"Custom", "Ecdsa", "Ed25519", "Ethereum", "Sr25519"}, names)
+}
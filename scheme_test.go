@@ -242,3 +242,31 @@ func Test_Generate_Sign_Verify(t *testing.T) {
 		verify(kr)
 	})
 }
+
+type foreignKeyPair struct {
+	subkey.KeyPair
+}
+
+func TestDerive_Chained(t *testing.T) {
+	for _, scheme := range []subkey.Scheme{sr25519.Scheme{}, ed25519.Scheme{}, ecdsa.Scheme{}} {
+		t.Run(scheme.String(), func(t *testing.T) {
+			kp, err := subkey.DeriveKeyPair(scheme, subkey.DevPhrase)
+			assert.NoError(t, err)
+			for _, path := range []string{"//org", "//team", "//member"} {
+				u, err := subkey.ParseSecretURI(path)
+				assert.NoError(t, err)
+				djs, err := u.DeriveJunctions()
+				assert.NoError(t, err)
+				kp, err = scheme.Derive(kp, djs)
+				assert.NoError(t, err)
+			}
+
+			want, err := subkey.DeriveKeyPair(scheme, "//org//team//member")
+			assert.NoError(t, err)
+			assert.Equal(t, want.Public(), kp.Public())
+
+			_, err = scheme.Derive(foreignKeyPair{kp}, nil)
+			assert.Error(t, err)
+		})
+	}
+}
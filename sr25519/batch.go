@@ -0,0 +1,66 @@
+package sr25519
+
+import (
+	"sort"
+
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
+	"github.com/gtank/merlin"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// VerifyBatch verifies the signatures with schnorrkel batch verification.
+// If the batch fails, the items are verified one by one to find the invalid ones.
+func (s Scheme) VerifyBatch(items []subkey.BatchItem) (failed []int) {
+	var (
+		indices     []int
+		transcripts []*merlin.Transcript
+		signatures  []*sr25519.Signature
+		pubkeys     []*sr25519.PublicKey
+	)
+
+	for i, item := range items {
+		sig, pk, ok := decodeBatchItem(item)
+		if !ok {
+			failed = append(failed, i)
+			continue
+		}
+
+		indices = append(indices, i)
+		transcripts = append(transcripts, signingContext(item.Message))
+		signatures = append(signatures, sig)
+		pubkeys = append(pubkeys, pk)
+	}
+
+	if ok, err := sr25519.VerifyBatch(transcripts, signatures, pubkeys); err == nil && ok {
+		return failed
+	}
+
+	for j, i := range indices {
+		if ok, err := pubkeys[j].Verify(signatures[j], signingContext(items[i].Message)); err != nil || !ok {
+			failed = append(failed, i)
+		}
+	}
+
+	sort.Ints(failed)
+	return failed
+}
+
+func decodeBatchItem(item subkey.BatchItem) (*sr25519.Signature, *sr25519.PublicKey, bool) {
+	if item.PublicKey == nil || len(item.Signature) != signatureLength {
+		return nil, nil, false
+	}
+
+	pk, err := publicKey(item.PublicKey)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	var sigs [signatureLength]byte
+	copy(sigs[:], item.Signature)
+	sig := new(sr25519.Signature)
+	if err := sig.Decode(sigs); err != nil {
+		return nil, nil, false
+	}
+
+	return sig, pk, true
+}
@@ -0,0 +1,56 @@
+package sr25519
+
+import (
+	"fmt"
+
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
+	"github.com/gtank/merlin"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// SignWithContext signs the message under the signing context label instead of `substrate`.
+func SignWithContext(pair subkey.KeyPair, context, msg []byte) ([]byte, error) {
+	return SignTranscript(pair, sr25519.NewSigningContext(context, msg))
+}
+
+// VerifyWithContext verifies the signature of the message made under the signing context label.
+func VerifyWithContext(pub subkey.PublicKey, context, msg, signature []byte) bool {
+	return VerifyTranscript(pub, sr25519.NewSigningContext(context, msg), signature)
+}
+
+// SignTranscript signs the caller built merlin transcript.
+// The transcript is consumed and cannot be reused for verification.
+func SignTranscript(pair subkey.KeyPair, t *merlin.Transcript) ([]byte, error) {
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := kr.secret.Sign(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transcript: %w", err)
+	}
+
+	s := sig.Encode()
+	return s[:], nil
+}
+
+// VerifyTranscript verifies the signature of the caller built merlin transcript.
+func VerifyTranscript(pub subkey.PublicKey, t *merlin.Transcript, signature []byte) bool {
+	pk, err := publicKey(pub)
+	if err != nil {
+		return false
+	}
+
+	return verify(pk, t, signature)
+}
+
+// publicKey decodes the schnorrkel public key of pub.
+func publicKey(pub subkey.PublicKey) (*sr25519.PublicKey, error) {
+	pk, err := Scheme{}.FromPublicKey(pub.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return pk.(*keyRing).pub, nil
+}
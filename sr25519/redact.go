@@ -0,0 +1,49 @@
+package sr25519
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// hasSecret reports whether the key ring holds a secret that was not wiped.
+func (kr keyRing) hasSecret() bool {
+	return kr.secret != nil && kr.secret.Encode() != [32]byte{}
+}
+
+// Wipe zeroes the seed and the secret key.
+func (kr keyRing) Wipe() {
+	subkey.Zero(kr.seed)
+	if kr.secret != nil {
+		*kr.secret = sr25519.SecretKey{}
+	}
+}
+
+// Close wipes the key ring.
+func (kr keyRing) Close() error {
+	kr.Wipe()
+	return nil
+}
+
+// String describes the key ring without its secret.
+func (kr keyRing) String() string {
+	return subkey.RedactedString(Scheme{}.String(), kr.Public(), kr.hasSecret())
+}
+
+// GoString describes the key ring without its secret.
+func (kr keyRing) GoString() string {
+	return kr.String()
+}
+
+// Format prints the key ring without its secret for every verb.
+func (kr keyRing) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, kr.String())
+}
+
+// LogValue logs the key ring without its secret.
+func (kr keyRing) LogValue() slog.Value {
+	return subkey.RedactedLogValue(Scheme{}.String(), kr.Public(), kr.hasSecret())
+}
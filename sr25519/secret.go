@@ -0,0 +1,75 @@
+package sr25519
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// FromEd25519Bytes returns the key pair of a 64 byte secret key in the ed25519 compatible form,
+// the key scalar multiplied by the cofactor followed by the nonce.
+// It matches schnorrkel's SecretKey::from_ed25519_bytes, the form used by polkadot.js.
+// The Seed of the key pair is the 64 byte secret key accepted by FromSeed.
+func FromEd25519Bytes(b []byte) (subkey.KeyPair, error) {
+	if len(b) != secretKeyLength {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidSeedLength, Got: len(b), Want: []int{secretKeyLength}}
+	}
+
+	seed := make([]byte, secretKeyLength)
+	copy(seed, b)
+	divideScalarByCofactor(seed[:32])
+	return Scheme{}.FromSeed(seed)
+}
+
+// Ed25519Bytes returns the 64 byte secret key of the key pair in the ed25519 compatible form.
+// It is the inverse of FromEd25519Bytes and matches schnorrkel's SecretKey::to_ed25519_bytes.
+// Soft derived key pairs do not keep their nonce so a random one is used.
+func Ed25519Bytes(pair subkey.KeyPair) ([]byte, error) {
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	key := kr.secret.Encode()
+	b := make([]byte, secretKeyLength)
+	copy(b, key[:])
+	multiplyScalarByCofactor(b[:32])
+
+	switch len(kr.seed) {
+	case miniSecretKeyLength:
+		h := sha512.Sum512(kr.seed)
+		copy(b[32:], h[32:])
+	case secretKeyLength:
+		copy(b[32:], kr.seed[32:])
+	default:
+		if _, err := rand.Read(b[32:]); err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+	}
+
+	return b, nil
+}
+
+// multiplyScalarByCofactor multiplies the little endian scalar by 8 in place.
+func multiplyScalarByCofactor(s []byte) {
+	var high byte
+	for i := range s {
+		r := s[i] & 0xe0
+		s[i] <<= 3
+		s[i] += high
+		high = r >> 5
+	}
+}
+
+// divideScalarByCofactor divides the little endian scalar by 8 in place.
+func divideScalarByCofactor(s []byte) {
+	var low byte
+	for i := len(s) - 1; i >= 0; i-- {
+		r := s[i] & 0x07
+		s[i] >>= 3
+		s[i] += low
+		low = r << 5
+	}
+}
@@ -1,7 +1,6 @@
 package sr25519
 
 import (
-	"errors"
 	"fmt"
 
 	sr25519 "github.com/ChainSafe/go-schnorrkel"
@@ -15,6 +14,8 @@ const (
 	secretKeyLength = 64
 
 	signatureLength = 64
+
+	publicKeyLength = 32
 )
 
 type keyRing struct {
@@ -24,6 +25,10 @@ type keyRing struct {
 }
 
 func (kr keyRing) Sign(msg []byte) (signature []byte, err error) {
+	if !kr.hasSecret() {
+		return nil, subkey.ErrMissingSecret
+	}
+
 	sig, err := kr.secret.Sign(signingContext(msg))
 	if err != nil {
 		return signature, err
@@ -34,13 +39,21 @@ func (kr keyRing) Sign(msg []byte) (signature []byte, err error) {
 }
 
 func (kr keyRing) Verify(msg []byte, signature []byte) bool {
+	return verify(kr.pub, signingContext(msg), signature)
+}
+
+func verify(pub *sr25519.PublicKey, t *merlin.Transcript, signature []byte) bool {
+	if len(signature) != signatureLength {
+		return false
+	}
+
 	var sigs [signatureLength]byte
 	copy(sigs[:], signature)
 	sig := new(sr25519.Signature)
 	if err := sig.Decode(sigs); err != nil {
 		return false
 	}
-	ok, err := kr.pub.Verify(sig, signingContext(msg))
+	ok, err := pub.Verify(sig, t)
 	if err != nil || !ok {
 		return false
 	}
@@ -59,7 +72,12 @@ func (kr keyRing) Public() []byte {
 }
 
 func (kr keyRing) Seed() []byte {
-	return kr.seed
+	if !kr.hasSecret() {
+		return nil
+	}
+
+	// a copy, so wiping the key ring does not zero the caller's seed
+	return append([]byte(nil), kr.seed...)
 }
 
 func (kr keyRing) AccountID() []byte {
@@ -67,7 +85,8 @@ func (kr keyRing) AccountID() []byte {
 }
 
 func (kr keyRing) SS58Address(network uint16) string {
-	return subkey.SS58Encode(kr.AccountID(), network)
+	address, _ := subkey.SS58EncodeStrict(kr.AccountID(), network)
+	return address
 }
 
 func deriveKeySoft(secret *sr25519.SecretKey, cc [32]byte) (*sr25519.SecretKey, error) {
@@ -80,6 +99,16 @@ func deriveKeySoft(secret *sr25519.SecretKey, cc [32]byte) (*sr25519.SecretKey,
 	return ek.Secret()
 }
 
+func deriveKeySoftPublic(pub *sr25519.PublicKey, cc [32]byte) (*sr25519.PublicKey, error) {
+	t := merlin.NewTranscript("SchnorrRistrettoHDKD")
+	t.AppendMessage([]byte("sign-bytes"), nil)
+	ek, err := pub.DeriveKey(t, cc)
+	if err != nil {
+		return nil, err
+	}
+	return ek.Public()
+}
+
 func deriveKeyHard(secret *sr25519.SecretKey, cc [32]byte) (*sr25519.MiniSecretKey, error) {
 	t := merlin.NewTranscript("SchnorrRistrettoHDKD")
 	t.AppendMessage([]byte("sign-bytes"), nil)
@@ -92,6 +121,32 @@ func deriveKeyHard(secret *sr25519.SecretKey, cc [32]byte) (*sr25519.MiniSecretK
 	return sr25519.NewMiniSecretKeyFromRaw(msk)
 }
 
+// toKeyRing returns the keyRing behind a key pair produced by this scheme.
+func toKeyRing(pair subkey.KeyPair) (keyRing, error) {
+	var kr keyRing
+	switch v := pair.(type) {
+	case keyRing:
+		kr = v
+	case *keyRing:
+		if v == nil {
+			return kr, fmt.Errorf("%w: nil", subkey.ErrUnsupportedKeyPair)
+		}
+		kr = *v
+	default:
+		return kr, fmt.Errorf("%w: %T", subkey.ErrUnsupportedKeyPair, pair)
+	}
+
+	if !kr.hasSecret() {
+		return kr, subkey.ErrMissingSecret
+	}
+
+	return kr, nil
+}
+
+func init() {
+	subkey.RegisterScheme(Scheme{})
+}
+
 type Scheme struct{}
 
 func (s Scheme) String() string {
@@ -119,13 +174,15 @@ func (s Scheme) Generate() (subkey.KeyPair, error) {
 }
 
 func (s Scheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
+	// keep a copy so wiping the key pair and the caller's buffer do not affect each other
+	seed = append([]byte(nil), seed...)
 	switch len(seed) {
 	case miniSecretKeyLength:
 		var mss [32]byte
 		copy(mss[:], seed)
 		ms, err := sr25519.NewMiniSecretKeyFromRaw(mss)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidSeed, err)
 		}
 
 		return keyRing{
@@ -141,7 +198,7 @@ func (s Scheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
 		secret := sr25519.NewSecretKey(key, nonce)
 		pub, err := secret.Public()
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidSeed, err)
 		}
 
 		return keyRing{
@@ -151,13 +208,17 @@ func (s Scheme) FromSeed(seed []byte) (subkey.KeyPair, error) {
 		}, nil
 	}
 
-	return nil, errors.New("invalid seed length")
+	return nil, &subkey.LengthError{
+		Err:  subkey.ErrInvalidSeedLength,
+		Got:  len(seed),
+		Want: []int{miniSecretKeyLength, secretKeyLength},
+	}
 }
 
 func (s Scheme) FromPhrase(phrase, pwd string) (subkey.KeyPair, error) {
 	ms, err := sr25519.MiniSecretKeyFromMnemonic(phrase, pwd)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidPhrase, err)
 	}
 
 	secret := ms.ExpandEd25519()
@@ -175,10 +236,13 @@ func (s Scheme) FromPhrase(phrase, pwd string) (subkey.KeyPair, error) {
 }
 
 func (s Scheme) Derive(pair subkey.KeyPair, djs []subkey.DeriveJunction) (subkey.KeyPair, error) {
-	kr := pair.(keyRing)
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return nil, err
+	}
+
 	secret := kr.secret
 	seed := kr.seed
-	var err error
 	for _, dj := range djs {
 		if dj.IsHard {
 			ms, err := deriveKeyHard(secret, dj.ChainCode)
@@ -210,15 +274,48 @@ func (s Scheme) Derive(pair subkey.KeyPair, djs []subkey.DeriveJunction) (subkey
 }
 
 func (s Scheme) FromPublicKey(bytes []byte) (subkey.PublicKey, error) {
-	if len(bytes) != 32 {
-		return nil, fmt.Errorf("expected 32 bytes")
+	if len(bytes) != publicKeyLength {
+		return nil, &subkey.LengthError{Err: subkey.ErrInvalidPublicKey, Got: len(bytes), Want: []int{publicKeyLength}}
 	}
-	arr := [32]byte{}
-	copy(arr[:], bytes[:32])
+	arr := [publicKeyLength]byte{}
+	copy(arr[:], bytes)
 	key, err := sr25519.NewPublicKey(arr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidPublicKey, err)
+	}
+
+	return &keyRing{pub: key}, nil
+}
+
+// DerivePublic derives the public key using soft junctions only.
+// The result matches the public key of Derive on the corresponding key pair.
+func (s Scheme) DerivePublic(pub subkey.PublicKey, djs []subkey.DeriveJunction) (subkey.PublicKey, error) {
+	pk, err := s.FromPublicKey(pub.Public())
 	if err != nil {
 		return nil, err
 	}
 
+	key := pk.(*keyRing).pub
+	for _, dj := range djs {
+		if dj.IsHard {
+			return nil, subkey.ErrHardJunctionNotSupported
+		}
+
+		key, err = deriveKeySoftPublic(key, dj.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &keyRing{pub: key}, nil
 }
+
+// VerifyAccount verifies the signature against the account ID, which is the public key for this scheme.
+func (s Scheme) VerifyAccount(accountID, msg, signature []byte) bool {
+	pub, err := s.FromPublicKey(accountID)
+	if err != nil {
+		return false
+	}
+
+	return pub.Verify(msg, signature)
+}
@@ -1,8 +1,10 @@
 package sr25519
 
 import (
+	"crypto/sha512"
 	"testing"
 
+	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/assert"
 	"github.com/vedhavyas/go-subkey/v2"
 )
@@ -24,6 +26,14 @@ func TestFromPublicKeyVerifyGood(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, pubkey.SS58Address(network), addr)
 	assert.True(t, pubkey.Verify(msg, sig))
+
+	// formats are not masked into other networks
+	assert.Empty(t, pubkey.SS58Address(16_384))
+	assert.Empty(t, pubkey.SS58Address(46))
+	_, err = subkey.SS58EncodeStrict(pubkey.AccountID(), 16_384)
+	assert.ErrorIs(t, err, subkey.ErrInvalidFormat)
+	_, err = subkey.SS58EncodeStrict(pubkey.AccountID(), 46)
+	assert.ErrorIs(t, err, subkey.ErrReservedFormat)
 }
 
 func TestVerifyBad(t *testing.T) {
@@ -49,3 +59,102 @@ func TestVerifyBad(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, badPubkey.Verify(msg, sig))
 }
+
+func TestDerivePublic(t *testing.T) {
+	kr, err := subkey.DeriveKeyPair(Scheme{}, "//Alice")
+	assert.NoError(t, err)
+
+	u, err := subkey.ParseSecretURI("/deposit/42/foo")
+	assert.NoError(t, err)
+	djs, err := u.DeriveJunctions()
+	assert.NoError(t, err)
+
+	pub, err := Scheme{}.DerivePublic(kr, djs)
+	assert.NoError(t, err)
+
+	derived, err := subkey.DeriveKeyPair(Scheme{}, "//Alice/deposit/42/foo")
+	assert.NoError(t, err)
+	assert.Equal(t, derived.Public(), pub.Public())
+
+	msg := []byte("deposit")
+	sig, err := derived.Sign(msg)
+	assert.NoError(t, err)
+	assert.True(t, pub.Verify(msg, sig))
+
+	u, err = subkey.ParseSecretURI("/deposit//42")
+	assert.NoError(t, err)
+	djs, err = u.DeriveJunctions()
+	assert.NoError(t, err)
+	_, err = Scheme{}.DerivePublic(kr, djs)
+	assert.Error(t, err)
+}
+
+func TestSignWithContext(t *testing.T) {
+	kp, err := Scheme{}.Generate()
+	assert.NoError(t, err)
+	pub, err := Scheme{}.FromPublicKey(kp.Public())
+	assert.NoError(t, err)
+	msg := []byte("message")
+
+	sig, err := SignWithContext(kp, []byte("app"), msg)
+	assert.NoError(t, err)
+	assert.True(t, VerifyWithContext(pub, []byte("app"), msg, sig))
+	assert.False(t, VerifyWithContext(pub, []byte("other"), msg, sig))
+	assert.False(t, pub.Verify(msg, sig))
+
+	sig, err = SignWithContext(kp, []byte("substrate"), msg)
+	assert.NoError(t, err)
+	assert.True(t, pub.Verify(msg, sig))
+
+	newTranscript := func() *merlin.Transcript {
+		t := merlin.NewTranscript("BabeVRFInOutContext")
+		t.AppendMessage([]byte("slot number"), []byte{1, 0, 0, 0, 0, 0, 0, 0})
+		return t
+	}
+	sig, err = SignTranscript(kp, newTranscript())
+	assert.NoError(t, err)
+	assert.True(t, VerifyTranscript(pub, newTranscript(), sig))
+	assert.False(t, VerifyTranscript(pub, merlin.NewTranscript("other"), sig))
+}
+
+func TestEd25519Bytes(t *testing.T) {
+	// Alice's mini secret key
+	seed := fromHex(t, "0xe5be9a5092b81bca64be81d212e7f2f9eba183bb7a90954f7b76361f6edb5c0a")
+	kp, err := Scheme{}.FromSeed(seed)
+	assert.NoError(t, err)
+
+	b, err := Ed25519Bytes(kp)
+	assert.NoError(t, err)
+	// the ed25519 form is the clamped SHA-512 of the mini secret key
+	h := sha512.Sum512(seed)
+	h[0] &= 248
+	h[31] &= 63
+	h[31] |= 64
+	assert.Equal(t, h[:], b)
+
+	got, err := FromEd25519Bytes(b)
+	assert.NoError(t, err)
+	assert.Equal(t, kp.Public(), got.Public())
+	assert.Len(t, got.Seed(), secretKeyLength)
+	again, err := Ed25519Bytes(got)
+	assert.NoError(t, err)
+	assert.Equal(t, b, again)
+
+	derived, err := subkey.DeriveKeyPair(Scheme{}, "//Alice/soft")
+	assert.NoError(t, err)
+	b, err = Ed25519Bytes(derived)
+	assert.NoError(t, err)
+	got, err = FromEd25519Bytes(b)
+	assert.NoError(t, err)
+	assert.Equal(t, derived.Public(), got.Public())
+	sig, err := got.Sign([]byte("msg"))
+	assert.NoError(t, err)
+	assert.True(t, derived.Verify([]byte("msg"), sig))
+
+	_, err = FromEd25519Bytes(seed)
+	assert.ErrorIs(t, err, subkey.ErrInvalidSeedLength)
+	pub, err := Scheme{}.FromPublicKey(kp.Public())
+	assert.NoError(t, err)
+	_, err = Ed25519Bytes(pub.(subkey.KeyPair))
+	assert.ErrorIs(t, err, subkey.ErrMissingSecret)
+}
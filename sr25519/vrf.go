@@ -0,0 +1,133 @@
+package sr25519
+
+import (
+	"fmt"
+
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
+	"github.com/gtank/merlin"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+const (
+	// VrfPreOutputLength is the length of an encoded VRF pre-output.
+	VrfPreOutputLength = 32
+
+	// VrfProofLength is the length of an encoded VRF proof.
+	VrfProofLength = 64
+
+	// VrfSignatureLength is the length of an encoded VrfSignature.
+	VrfSignatureLength = VrfPreOutputLength + VrfProofLength
+)
+
+// VrfTranscriptItem is a labeled message appended to a VrfTranscript.
+type VrfTranscriptItem struct {
+	Label []byte
+	Data  []byte
+}
+
+// VrfTranscript is the VRF input, matching sp_core::sr25519::vrf::VrfTranscript.
+// Merlin transcripts are consumed when used, so it keeps the items to build a fresh one for every call.
+type VrfTranscript struct {
+	Label string
+	Items []VrfTranscriptItem
+}
+
+// NewVrfTranscript returns the transcript with the label and items.
+func NewVrfTranscript(label string, items ...VrfTranscriptItem) *VrfTranscript {
+	return &VrfTranscript{Label: label, Items: items}
+}
+
+// Transcript returns a fresh merlin transcript with the label and items appended in order.
+func (vt *VrfTranscript) Transcript() *merlin.Transcript {
+	t := merlin.NewTranscript(vt.Label)
+	for _, item := range vt.Items {
+		t.AppendMessage(item.Label, item.Data)
+	}
+
+	return t
+}
+
+// VrfSignature is the VRF pre-output and proof, matching sp_core::sr25519::vrf::VrfSignature.
+type VrfSignature struct {
+	PreOutput [VrfPreOutputLength]byte
+	Proof     [VrfProofLength]byte
+}
+
+// Encode returns the SCALE encoding of the signature, the pre-output followed by the proof.
+func (vs VrfSignature) Encode() []byte {
+	b := make([]byte, 0, VrfSignatureLength)
+	b = append(b, vs.PreOutput[:]...)
+	return append(b, vs.Proof[:]...)
+}
+
+// DecodeVrfSignature decodes the SCALE encoded VrfSignature.
+func DecodeVrfSignature(b []byte) (VrfSignature, error) {
+	var vs VrfSignature
+	if len(b) != VrfSignatureLength {
+		return vs, &subkey.LengthError{Err: subkey.ErrInvalidSignatureLength, Got: len(b), Want: []int{VrfSignatureLength}}
+	}
+
+	copy(vs.PreOutput[:], b[:VrfPreOutputLength])
+	copy(vs.Proof[:], b[VrfPreOutputLength:])
+	return vs, nil
+}
+
+// VrfSign signs the transcript and returns the VRF pre-output and proof.
+func VrfSign(pair subkey.KeyPair, vt *VrfTranscript) (VrfSignature, error) {
+	var vs VrfSignature
+	kr, err := toKeyRing(pair)
+	if err != nil {
+		return vs, err
+	}
+
+	inout, proof, err := kr.secret.VrfSign(vt.Transcript())
+	if err != nil {
+		return vs, fmt.Errorf("failed to sign vrf transcript: %w", err)
+	}
+
+	vs.PreOutput = inout.Output().Encode()
+	vs.Proof = proof.Encode()
+	return vs, nil
+}
+
+// VrfVerify verifies the VRF signature of the transcript.
+func VrfVerify(pub subkey.PublicKey, vt *VrfTranscript, vs VrfSignature) bool {
+	pk, err := publicKey(pub)
+	if err != nil {
+		return false
+	}
+
+	out, err := sr25519.NewOutput(vs.PreOutput)
+	if err != nil {
+		return false
+	}
+
+	proof := new(sr25519.VrfProof)
+	if err := proof.Decode(vs.Proof); err != nil {
+		return false
+	}
+
+	ok, err := pk.VrfVerify(vt.Transcript(), out, proof)
+	return err == nil && ok
+}
+
+// MakeBytes derives size bytes of randomness from the VRF pre-output of the transcript under the context.
+// It matches sp_core::sr25519::vrf::VrfPreOutput::make_bytes. The pre-output should be verified first.
+func MakeBytes(pub subkey.PublicKey, vt *VrfTranscript, preOutput [VrfPreOutputLength]byte, context []byte, size int) ([]byte, error) {
+	pk, err := publicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := sr25519.NewOutput(preOutput)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", subkey.ErrInvalidSignature, err)
+	}
+
+	inout, err := out.AttachInput(pk, vt.Transcript())
+	if err != nil {
+		return nil, err
+	}
+
+	return inout.MakeBytes(size, context)
+}
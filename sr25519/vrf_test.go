@@ -0,0 +1,78 @@
+package sr25519
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+func TestVrf(t *testing.T) {
+	kp, err := subkey.DeriveKeyPair(Scheme{}, "//Alice")
+	assert.NoError(t, err)
+	pub, err := Scheme{}.FromPublicKey(kp.Public())
+	assert.NoError(t, err)
+
+	vt := NewVrfTranscript("BABE",
+		VrfTranscriptItem{Label: []byte("slot number"), Data: []byte{1, 0, 0, 0, 0, 0, 0, 0}},
+		VrfTranscriptItem{Label: []byte("current epoch"), Data: []byte{2, 0, 0, 0, 0, 0, 0, 0}},
+	)
+
+	vs, err := VrfSign(kp, vt)
+	assert.NoError(t, err)
+	assert.True(t, VrfVerify(pub, vt, vs))
+
+	decoded, err := DecodeVrfSignature(vs.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, vs, decoded)
+
+	other := NewVrfTranscript("BABE", VrfTranscriptItem{Label: []byte("slot number"), Data: []byte{2}})
+	assert.False(t, VrfVerify(pub, other, vs))
+
+	bob, err := subkey.DeriveKeyPair(Scheme{}, "//Bob")
+	assert.NoError(t, err)
+	assert.False(t, VrfVerify(bob, vt, vs))
+
+	// the pre-output is deterministic so is the randomness
+	again, err := VrfSign(kp, vt)
+	assert.NoError(t, err)
+	assert.Equal(t, vs.PreOutput, again.PreOutput)
+
+	randomness, err := MakeBytes(pub, vt, vs.PreOutput, []byte("substrate-babe-vrf"), 32)
+	assert.NoError(t, err)
+	assert.Len(t, randomness, 32)
+	fromSigner, err := MakeBytes(kp, vt, again.PreOutput, []byte("substrate-babe-vrf"), 32)
+	assert.NoError(t, err)
+	assert.Equal(t, randomness, fromSigner)
+
+	_, err = MakeBytes(pub, vt, vs.PreOutput, nil, 65)
+	assert.Error(t, err)
+
+	_, err = DecodeVrfSignature(make([]byte, 64))
+	assert.ErrorIs(t, err, subkey.ErrInvalidSignatureLength)
+}
+
+func TestVrfRustVector(t *testing.T) {
+	// the vector of schnorrkel's vrf.rs tests, signed under signing_context(b"yo!").bytes(b"meow")
+	public := fromHex(t, "0x0c84b70beabe60ac6fefa38994a3454fe63d8629455a86e58480063f8bdcca00")
+	vs, err := DecodeVrfSignature(fromHex(t, "0x"+
+		"d62899f6584a7ff236c107055a332d05cf3b404486e813dff9584a7d404adc30"+
+		"90c7b305fac7dcb10cdcf2c4a8ed6a033ec34a7f866b895ba568dff403048d0a"+
+		"8136861f31facdcbfe8e577bd86cbe70ccccbc1e5424f7d93b7d2d3870c3540f"))
+	assert.NoError(t, err)
+
+	pub, err := Scheme{}.FromPublicKey(public)
+	assert.NoError(t, err)
+	vt := NewVrfTranscript("SigningContext",
+		VrfTranscriptItem{Label: []byte(""), Data: []byte("yo!")},
+		VrfTranscriptItem{Label: []byte("sign-bytes"), Data: []byte("meow")},
+	)
+	assert.True(t, VrfVerify(pub, vt, vs))
+
+	randomness, err := MakeBytes(pub, vt, vs.PreOutput, []byte("substrate-babe-vrf"), 16)
+	assert.NoError(t, err)
+	assert.Equal(t, fromHex(t, "0xa939953200f3788a19fa4aebf789e428"), randomness)
+
+	vs.Proof[0] ^= 1
+	assert.False(t, VrfVerify(pub, vt, vs))
+}
@@ -8,61 +8,149 @@ import (
 	"golang.org/x/crypto/blake2b"
 )
 
+const (
+	// maxSS58Format is the largest ident that fits in the two byte SS58 prefix.
+	maxSS58Format = 16_383
+)
+
+// ss58Layouts maps the length of a decoded address without its prefix
+// to the payload and checksum lengths as defined by the SS58 spec.
+// See https://docs.substrate.io/reference/address-formats/
+var ss58Layouts = map[int]struct{ payload, checksum int }{
+	2:  {1, 1},
+	3:  {2, 1},
+	4:  {2, 2},
+	5:  {4, 1},
+	6:  {4, 2},
+	7:  {4, 3},
+	8:  {4, 4},
+	9:  {8, 1},
+	10: {8, 2},
+	11: {8, 3},
+	12: {8, 4},
+	13: {8, 5},
+	14: {8, 6},
+	15: {8, 7},
+	16: {8, 8},
+	34: {32, 2},
+	35: {33, 2},
+}
+
+// ss58ChecksumLengths maps the supported payload lengths to the checksum length used when encoding.
+// Account indices use a single byte checksum, public keys and account IDs use two.
+var ss58ChecksumLengths = map[int]int{
+	1:  1,
+	2:  1,
+	4:  1,
+	8:  1,
+	32: 2,
+	33: 2,
+}
+
 // SS58Decode decodes an SS58 checksumed value into its data and format.
 func SS58Decode(address string) (uint16, []byte, error) {
 	// Adapted from https://github.com/paritytech/substrate/blob/e6def65920d30029e42d498cb07cec5dd433b927/primitives/core/src/crypto.rs#L264
 
 	data := base58.Decode(address)
 	if len(data) < 2 {
-		return 0, nil, fmt.Errorf("expected at least 2 bytes in base58 decoded address")
+		return 0, nil, fmt.Errorf("%w: expected at least 2 bytes in base58 decoded address", ErrInvalidAddress)
 	}
 
-	prefixLen := int8(0)
+	prefixLen := 0
 	ident := uint16(0)
 	if data[0] <= 63 {
 		prefixLen = 1
 		ident = uint16(data[0])
-	} else if data[0] < 127 {
+	} else if data[0] <= 127 {
 		lower := (data[0] << 2) | (data[1] >> 6)
 		upper := data[1] & 0b00111111
 		prefixLen = 2
 		ident = uint16(lower) | (uint16(upper) << 8)
 	} else {
-		return 0, nil, fmt.Errorf("invalid address")
+		return 0, nil, fmt.Errorf("%w: invalid prefix byte %d", ErrInvalidAddress, data[0])
+	}
+
+	if isReservedSS58Format(ident) {
+		return 0, nil, fmt.Errorf("%w: %w: %d", ErrInvalidAddress, ErrReservedFormat, ident)
+	}
+
+	layout, ok := ss58Layouts[len(data)-prefixLen]
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: %w: %d bytes after the prefix", ErrInvalidAddress, ErrInvalidPayloadLength, len(data)-prefixLen)
 	}
 
-	checkSumLength := 2
+	checkSumLength := layout.checksum
 	hash := ss58hash(data[:len(data)-checkSumLength])
 	checksum := hash[:checkSumLength]
 
 	givenChecksum := data[len(data)-checkSumLength:]
 	if !bytes.Equal(givenChecksum, checksum) {
-		return 0, nil, fmt.Errorf("checksum mismatch: expected %v but got %v", checksum, givenChecksum)
+		return 0, nil, &ChecksumError{Want: checksum, Got: givenChecksum}
 	}
 
 	return ident, data[prefixLen : len(data)-checkSumLength], nil
 }
 
 // SS58Encode encodes data and format identifier to an SS58 checksumed string.
+// It returns an empty string for the input SS58EncodeStrict rejects: formats above 16383,
+// the reserved formats 46 and 47, and payload lengths not covered by the SS58 spec.
+//
+// Deprecated: use SS58EncodeStrict, which reports why the input cannot be encoded.
 func SS58Encode(pubkey []byte, format uint16) string {
+	address, _ := SS58EncodeStrict(pubkey, format)
+	return address
+}
+
+// SS58EncodeStrict encodes data and format identifier to an SS58 checksumed string.
+// It returns an error for payload lengths not covered by the SS58 spec,
+// for the reserved formats 46 and 47, and for formats above 16383.
+func SS58EncodeStrict(payload []byte, format uint16) (string, error) {
+	if err := checkSS58Format(format); err != nil {
+		return "", err
+	}
+
+	checksumLen, ok := ss58ChecksumLengths[len(payload)]
+	if !ok {
+		return "", &LengthError{Err: ErrInvalidPayloadLength, Got: len(payload), Want: []int{1, 2, 4, 8, 32, 33}}
+	}
+
+	return ss58Encode(payload, format, checksumLen), nil
+}
+
+func ss58Encode(payload []byte, ident uint16, checksumLen int) string {
 	// Adapted from https://github.com/paritytech/substrate/blob/e6def65920d30029e42d498cb07cec5dd433b927/primitives/core/src/crypto.rs#L319
-	ident := format & 0b0011_1111_1111_1111
 	var prefix []byte
 	if ident <= 63 {
 		prefix = []byte{uint8(ident)}
-	} else if ident <= 16_383 {
+	} else {
 		// upper six bits of the lower byte(!)
 		first := uint8(ident&0b0000_0000_1111_1100) >> 2
 		// lower two bits of the lower byte in the high pos,
 		// lower bits of the upper byte in the low pos
 		second := uint8(ident>>8) | uint8(ident&0b0000_0000_0000_0011)<<6
 		prefix = []byte{first | 0b01000000, second}
-	} else {
-		panic("unreachable: masked out the upper two bits; qed")
 	}
-	body := append(prefix, pubkey...)
+	body := append(prefix, payload...)
 	hash := ss58hash(body)
-	return base58.Encode(append(body, hash[:2]...))
+	return base58.Encode(append(body, hash[:checksumLen]...))
+}
+
+// checkSS58Format returns an error for formats above 16383 and the reserved formats.
+func checkSS58Format(format uint16) error {
+	if format > maxSS58Format {
+		return fmt.Errorf("%w: %d exceeds %d", ErrInvalidFormat, format, maxSS58Format)
+	}
+
+	if isReservedSS58Format(format) {
+		return fmt.Errorf("%w: %d", ErrReservedFormat, format)
+	}
+
+	return nil
+}
+
+// isReservedSS58Format reports whether the format is reserved by the SS58 spec.
+func isReservedSS58Format(format uint16) bool {
+	return format == 46 || format == 47
 }
 
 func ss58hash(data []byte) [64]byte {
@@ -1,9 +1,12 @@
 package subkey
 
 import (
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/decred/base58"
 )
 
 func TestAddressInfo(t *testing.T) {
@@ -48,3 +51,77 @@ func TestAddressInfo(t *testing.T) {
 		t.Errorf("Expected checksum mismatch but got '%v'", err)
 	}
 }
+
+func TestSS58PayloadLengths(t *testing.T) {
+	for _, l := range []int{1, 2, 4, 8, 32, 33} {
+		payload := make([]byte, l)
+		for i := range payload {
+			payload[i] = byte(i + 1)
+		}
+
+		for _, format := range []uint16{0, 2, 42, 63, 64, 11820, 16_383} {
+			addr, err := SS58EncodeStrict(payload, format)
+			if err != nil {
+				t.Fatalf("encoding %d bytes with format %d failed: %v", l, format, err)
+			}
+
+			if got := SS58Encode(payload, format); got != addr {
+				t.Errorf("SS58Encode() = %v, want %v", got, addr)
+			}
+
+			gotFormat, gotPayload, err := SS58Decode(addr)
+			if err != nil {
+				t.Fatalf("decoding %v failed: %v", addr, err)
+			}
+
+			if gotFormat != format || !reflect.DeepEqual(gotPayload, payload) {
+				t.Errorf("SS58Decode(%v) = %v, %v, want %v, %v", addr, gotFormat, gotPayload, format, payload)
+			}
+		}
+	}
+
+	// two byte checksum for account indices is also allowed by the spec
+	body := append([]byte{42}, 1, 2)
+	hash := ss58hash(body)
+	_, payload, err := SS58Decode(base58.Encode(append(body, hash[:2]...)))
+	if err != nil || !reflect.DeepEqual(payload, []byte{1, 2}) {
+		t.Errorf("SS58Decode() = %v, %v, want [1 2]", payload, err)
+	}
+}
+
+func TestSS58EncodeStrictErrors(t *testing.T) {
+	tests := []struct {
+		payload []byte
+		format  uint16
+		err     error
+	}{
+		{make([]byte, 32), 46, ErrReservedFormat},
+		{make([]byte, 32), 47, ErrReservedFormat},
+		{make([]byte, 32), 16_384, ErrInvalidFormat},
+		{make([]byte, 20), 42, ErrInvalidPayloadLength},
+		{nil, 42, ErrInvalidPayloadLength},
+	}
+
+	for _, tt := range tests {
+		if _, err := SS58EncodeStrict(tt.payload, tt.format); !errors.Is(err, tt.err) {
+			t.Errorf("SS58EncodeStrict(%d bytes, %d) error = %v, want %v", len(tt.payload), tt.format, err, tt.err)
+		}
+	}
+
+	_, _, err := SS58Decode(ss58Encode(make([]byte, 32), 46, 2))
+	if !errors.Is(err, ErrReservedFormat) {
+		t.Errorf("Expected reserved format but got '%v'", err)
+	}
+
+	_, _, err = SS58Decode(ss58Encode(make([]byte, 20), 42, 2))
+	if !errors.Is(err, ErrInvalidPayloadLength) {
+		t.Errorf("Expected invalid payload length but got '%v'", err)
+	}
+
+	// SS58Encode does not mask the format into a valid one
+	for _, tt := range tests {
+		if got := SS58Encode(tt.payload, tt.format); got != "" {
+			t.Errorf("SS58Encode(%d bytes, %d) = %v, want empty", len(tt.payload), tt.format, got)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package subkey
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ss58RegistryJSON is the registry file of https://github.com/paritytech/ss58-registry.
+// The copy in the tree only holds the commonly used entries; go generate replaces it
+// with the full file of the pinned upstream release.
+//
+//go:generate curl -sSfL -o ss58-registry.json https://raw.githubusercontent.com/paritytech/ss58-registry/v1.51.0/ss58-registry.json
+//go:embed ss58-registry.json
+var ss58RegistryJSON []byte
+
+// Network is an entry of the SS58 registry.
+type Network struct {
+	// Prefix is the SS58 format of the network.
+	Prefix uint16 `json:"prefix"`
+	// Name is the network identifier, such as `polkadot`.
+	Name string `json:"network"`
+	// DisplayName is the human readable network name.
+	DisplayName string `json:"displayName"`
+	// Symbols are the token symbols of the network.
+	Symbols []string `json:"symbols"`
+	// Decimals are the token decimals, in the same order as Symbols.
+	Decimals []int `json:"decimals"`
+	// StandardAccount is the account type used by the network, such as `*25519` or `secp256k1`.
+	StandardAccount string `json:"standardAccount"`
+	// Website of the network.
+	Website string `json:"website"`
+}
+
+type ss58Registry struct {
+	networks []Network
+	byName   map[string]Network
+	byPrefix map[uint16]Network
+}
+
+var registry = mustLoadSS58Registry(ss58RegistryJSON)
+
+func mustLoadSS58Registry(data []byte) ss58Registry {
+	var file struct {
+		Registry []Network `json:"registry"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		panic(fmt.Sprintf("invalid embedded ss58 registry: %v", err))
+	}
+
+	r := ss58Registry{
+		networks: file.Registry,
+		byName:   make(map[string]Network, len(file.Registry)),
+		byPrefix: make(map[uint16]Network, len(file.Registry)),
+	}
+	sort.Slice(r.networks, func(i, j int) bool {
+		return r.networks[i].Prefix < r.networks[j].Prefix
+	})
+	for _, n := range r.networks {
+		r.byName[strings.ToLower(n.Name)] = n
+		r.byPrefix[n.Prefix] = n
+	}
+
+	return r
+}
+
+// Networks returns the networks of the SS58 registry ordered by prefix.
+func Networks() []Network {
+	networks := make([]Network, len(registry.networks))
+	copy(networks, registry.networks)
+	return networks
+}
+
+// NetworkByName returns the network with the given name. The lookup is case-insensitive.
+func NetworkByName(name string) (Network, bool) {
+	n, ok := registry.byName[strings.ToLower(name)]
+	return n, ok
+}
+
+// NetworkByPrefix returns the network with the given SS58 prefix.
+func NetworkByPrefix(prefix uint16) (Network, bool) {
+	n, ok := registry.byPrefix[prefix]
+	return n, ok
+}
+
+// AddressFor returns the SS58 address of the public key on the named network.
+func AddressFor(pub PublicKey, network string) (string, error) {
+	n, ok := NetworkByName(network)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	return SS58EncodeStrict(pub.AccountID(), n.Prefix)
+}
+
+// DecodeNetworkAddress decodes the SS58 address and looks up its network.
+// ErrUnknownNetwork is returned, along with the prefix in Network.Prefix, if the prefix is not registered.
+func DecodeNetworkAddress(address string) (Network, []byte, error) {
+	prefix, payload, err := SS58Decode(address)
+	if err != nil {
+		return Network{}, nil, err
+	}
+
+	n, ok := NetworkByPrefix(prefix)
+	if !ok {
+		return Network{Prefix: prefix}, payload, fmt.Errorf("%w: prefix %d", ErrUnknownNetwork, prefix)
+	}
+
+	return n, payload, nil
+}
@@ -0,0 +1,54 @@
+package subkey_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func TestNetworkRegistry(t *testing.T) {
+	n, ok := subkey.NetworkByName("Kusama")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(2), n.Prefix)
+	assert.Equal(t, []string{"KSM"}, n.Symbols)
+	assert.Equal(t, []int{12}, n.Decimals)
+
+	n, ok = subkey.NetworkByPrefix(0)
+	assert.True(t, ok)
+	assert.Equal(t, "polkadot", n.Name)
+
+	_, ok = subkey.NetworkByName("unknown")
+	assert.False(t, ok)
+
+	networks := subkey.Networks()
+	for i := 1; i < len(networks); i++ {
+		assert.Less(t, networks[i-1].Prefix, networks[i].Prefix)
+	}
+}
+
+func TestAddressFor(t *testing.T) {
+	kr, err := subkey.DeriveKeyPair(sr25519.Scheme{}, "//Alice")
+	assert.NoError(t, err)
+
+	addr, err := subkey.AddressFor(kr, "substrate")
+	assert.NoError(t, err)
+	assert.Equal(t, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", addr)
+
+	addr, err = subkey.AddressFor(kr, "heiko")
+	assert.NoError(t, err)
+	assert.Equal(t, "hJKzPoi3MQnSLvbShxeDmzbtHncrMXe5zwS3Wa36P6kXeNpcv", addr)
+
+	n, pub, err := subkey.DecodeNetworkAddress(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, "heiko", n.Name)
+	assert.Equal(t, kr.AccountID(), pub)
+
+	_, err = subkey.AddressFor(kr, "unknown")
+	assert.ErrorIs(t, err, subkey.ErrUnknownNetwork)
+
+	n, _, err = subkey.DecodeNetworkAddress(subkey.SS58Encode(kr.AccountID(), 9999))
+	assert.ErrorIs(t, err, subkey.ErrUnknownNetwork)
+	assert.Equal(t, uint16(9999), n.Prefix)
+}
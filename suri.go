@@ -0,0 +1,201 @@
+package subkey
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Junction is a single derivation path segment of a SecretURI.
+type Junction struct {
+	// Code is the junction as written in the URI, without the leading slashes.
+	Code string
+	// Hard is true for `//` junctions and false for `/` junctions.
+	Hard bool
+}
+
+// HardJunction returns a hard junction for the code.
+func HardJunction(code string) Junction {
+	return Junction{Code: code, Hard: true}
+}
+
+// SoftJunction returns a soft junction for the code.
+func SoftJunction(code string) Junction {
+	return Junction{Code: code}
+}
+
+// String returns the junction as it appears in a URI.
+func (j Junction) String() string {
+	if j.Hard {
+		return "//" + j.Code
+	}
+
+	return "/" + j.Code
+}
+
+// DeriveJunction returns the chain code and hardness used by Scheme.Derive.
+func (j Junction) DeriveJunction() (DeriveJunction, error) {
+	code := j.Code
+	if j.Hard {
+		code = "/" + code
+	}
+
+	return parseDeriveJunction(code)
+}
+
+// URIError is returned when a secret URI is malformed.
+// Pos is the byte offset in URI where parsing failed.
+type URIError struct {
+	URI string
+	Pos int
+	Msg string
+}
+
+func (e *URIError) Error() string {
+	return fmt.Sprintf("%v at position %d: %s", ErrInvalidURI, e.Pos, e.Msg)
+}
+
+func (e *URIError) Unwrap() error {
+	return ErrInvalidURI
+}
+
+// SecretURI is a parsed secret URI of the form `phrase//hard/soft///password`.
+// The phrase may also be a hex encoded seed.
+type SecretURI struct {
+	// Phrase is the mnemonic phrase or hex seed. Empty means DevPhrase.
+	Phrase string
+	// Junctions is the derivation path in order.
+	Junctions []Junction
+	// Password is the optional password. An empty password is omitted by String.
+	Password string
+}
+
+// ParseSecretURI parses the secret URI.
+func ParseSecretURI(suri string) (SecretURI, error) {
+	var u SecretURI
+	i := 0
+	for i < len(suri) && isPhraseChar(suri[i]) {
+		i++
+	}
+	u.Phrase = suri[:i]
+
+	for i < len(suri) {
+		if suri[i] != '/' {
+			return SecretURI{}, &URIError{URI: suri, Pos: i, Msg: fmt.Sprintf("unexpected character %q", suri[i])}
+		}
+
+		if strings.HasPrefix(suri[i:], "///") {
+			u.Password = suri[i+3:]
+			break
+		}
+
+		var j Junction
+		i++
+		if i < len(suri) && suri[i] == '/' {
+			j.Hard = true
+			i++
+		}
+
+		start := i
+		for i < len(suri) && suri[i] != '/' {
+			i++
+		}
+		if start == i {
+			return SecretURI{}, &URIError{URI: suri, Pos: start, Msg: "empty junction"}
+		}
+
+		j.Code = suri[start:i]
+		u.Junctions = append(u.Junctions, j)
+	}
+
+	return u, nil
+}
+
+// Validate checks that the URI can be rendered and parsed back unchanged.
+func (u SecretURI) Validate() error {
+	s := u.String()
+	for i := 0; i < len(u.Phrase); i++ {
+		if !isPhraseChar(u.Phrase[i]) {
+			return &URIError{URI: s, Pos: i, Msg: fmt.Sprintf("unexpected character %q in phrase", u.Phrase[i])}
+		}
+	}
+
+	pos := len(u.Phrase)
+	for _, j := range u.Junctions {
+		js := j.String()
+		if j.Code == "" {
+			return &URIError{URI: s, Pos: pos + len(js), Msg: "empty junction"}
+		}
+
+		if idx := strings.IndexByte(j.Code, '/'); idx >= 0 {
+			return &URIError{URI: s, Pos: pos + len(js) - len(j.Code) + idx, Msg: "unexpected '/' in junction"}
+		}
+
+		pos += len(js)
+	}
+
+	return nil
+}
+
+// String returns the URI in its textual form.
+func (u SecretURI) String() string {
+	var sb strings.Builder
+	sb.WriteString(u.Phrase)
+	sb.WriteString(u.Path())
+	if u.Password != "" {
+		sb.WriteString("///")
+		sb.WriteString(u.Password)
+	}
+
+	return sb.String()
+}
+
+// PhraseOrDev returns the phrase, or DevPhrase if the phrase is empty.
+func (u SecretURI) PhraseOrDev() string {
+	if u.Phrase == "" {
+		return DevPhrase
+	}
+
+	return u.Phrase
+}
+
+// HexSeed returns the decoded seed if the phrase is hex encoded.
+func (u SecretURI) HexSeed() ([]byte, bool) {
+	if u.Phrase == "" {
+		return nil, false
+	}
+
+	return DecodeHex(u.Phrase)
+}
+
+// Path returns the derivation path as it appears in the URI.
+func (u SecretURI) Path() string {
+	var sb strings.Builder
+	for _, j := range u.Junctions {
+		sb.WriteString(j.String())
+	}
+
+	return sb.String()
+}
+
+// DeriveJunctions returns the derive junctions for the path.
+func (u SecretURI) DeriveJunctions() ([]DeriveJunction, error) {
+	var djs []DeriveJunction
+	for _, j := range u.Junctions {
+		dj, err := j.DeriveJunction()
+		if err != nil {
+			return nil, err
+		}
+
+		djs = append(djs, dj)
+	}
+
+	return djs, nil
+}
+
+// isPhraseChar reports whether c may appear in the phrase part of a URI.
+func isPhraseChar(c byte) bool {
+	return c == ' ' || c == '_' ||
+		('0' <= c && c <= '9') ||
+		('a' <= c && c <= 'z') ||
+		('A' <= c && c <= 'Z')
+}
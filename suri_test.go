@@ -0,0 +1,81 @@
+package subkey
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecretURI(t *testing.T) {
+	tests := []struct {
+		suri      string
+		phrase    string
+		junctions []Junction
+		password  string
+	}{
+		{
+			suri:   DevPhrase,
+			phrase: DevPhrase,
+		},
+		{
+			suri:      "phrase//foo/bar///pwd",
+			phrase:    "phrase",
+			junctions: []Junction{HardJunction("foo"), SoftJunction("bar")},
+			password:  "pwd",
+		},
+		{
+			suri:      "//Alice//stash",
+			junctions: []Junction{HardJunction("Alice"), HardJunction("stash")},
+		},
+		{
+			suri:     "0x18446f2d685492c3086391aabe8f5e235c3c2e02521985650f0c97052237e717///a/b//c",
+			phrase:   "0x18446f2d685492c3086391aabe8f5e235c3c2e02521985650f0c97052237e717",
+			password: "a/b//c",
+		},
+	}
+
+	for _, c := range tests {
+		t.Run(c.suri, func(t *testing.T) {
+			u, err := ParseSecretURI(c.suri)
+			assert.NoError(t, err)
+			assert.Equal(t, c.phrase, u.Phrase)
+			assert.Equal(t, c.junctions, u.Junctions)
+			assert.Equal(t, c.password, u.Password)
+			assert.NoError(t, u.Validate())
+			assert.Equal(t, c.suri, u.String())
+		})
+	}
+}
+
+func TestParseSecretURI_Errors(t *testing.T) {
+	tests := []struct {
+		suri string
+		pos  int
+	}{
+		{suri: "phrase!", pos: 6},
+		{suri: "phrase/", pos: 7},
+		{suri: "phrase//", pos: 8},
+		{suri: "phrase//foo/", pos: 12},
+	}
+
+	for _, c := range tests {
+		t.Run(c.suri, func(t *testing.T) {
+			_, err := ParseSecretURI(c.suri)
+			var uerr *URIError
+			assert.True(t, errors.As(err, &uerr))
+			assert.Equal(t, c.pos, uerr.Pos)
+		})
+	}
+}
+
+func TestSecretURI_Validate(t *testing.T) {
+	u := SecretURI{Phrase: "phrase", Junctions: []Junction{HardJunction("foo"), SoftJunction("b/r")}}
+	var uerr *URIError
+	assert.True(t, errors.As(u.Validate(), &uerr))
+	assert.Equal(t, 13, uerr.Pos)
+
+	u.Junctions[1] = SoftJunction("bar")
+	assert.NoError(t, u.Validate())
+	assert.Equal(t, "phrase//foo/bar", u.String())
+}
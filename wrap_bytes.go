@@ -0,0 +1,76 @@
+package subkey
+
+import "bytes"
+
+// The markers polkadot.js wraps raw messages in before signing them, see u8aWrapBytes.
+var (
+	bytesPrefix = []byte("<Bytes>")
+	bytesSuffix = []byte("</Bytes>")
+)
+
+// WrapMode selects how a message relates to the <Bytes> wrapping when verifying.
+type WrapMode int
+
+const (
+	// WrapNone verifies the message as is.
+	WrapNone WrapMode = iota
+	// WrapAlways verifies the message wrapped in <Bytes>, as signed by polkadot.js signRaw.
+	WrapAlways
+	// WrapEither accepts signatures over either the message as is or its wrapped form.
+	WrapEither
+)
+
+// IsWrappedBytes reports whether msg is wrapped in <Bytes> and </Bytes>.
+func IsWrappedBytes(msg []byte) bool {
+	return len(msg) >= len(bytesPrefix)+len(bytesSuffix) &&
+		bytes.HasPrefix(msg, bytesPrefix) && bytes.HasSuffix(msg, bytesSuffix)
+}
+
+// WrapBytes returns msg wrapped in <Bytes> and </Bytes>.
+// Like polkadot.js, a message that is already wrapped is returned unchanged.
+func WrapBytes(msg []byte) []byte {
+	if IsWrappedBytes(msg) {
+		return msg
+	}
+
+	b := make([]byte, 0, len(bytesPrefix)+len(msg)+len(bytesSuffix))
+	b = append(b, bytesPrefix...)
+	b = append(b, msg...)
+	return append(b, bytesSuffix...)
+}
+
+// UnwrapBytes returns msg without the <Bytes> wrapping, or msg itself if it is not wrapped.
+func UnwrapBytes(msg []byte) []byte {
+	if !IsWrappedBytes(msg) {
+		return msg
+	}
+
+	return msg[len(bytesPrefix) : len(msg)-len(bytesSuffix)]
+}
+
+// SignWrapped signs msg wrapped in <Bytes>, producing the same signature as polkadot.js signRaw.
+func SignWrapped(signer Signer, msg []byte) ([]byte, error) {
+	return signer.Sign(WrapBytes(msg))
+}
+
+// VerifyWrapped verifies a signature made over msg wrapped in <Bytes>.
+func VerifyWrapped(verifier Verifier, msg, signature []byte) bool {
+	return verifier.Verify(WrapBytes(msg), signature)
+}
+
+// VerifyMode verifies the signature of msg following the wrap mode.
+// With WrapEither, a wrapped msg is also checked without the wrapping.
+func VerifyMode(verifier Verifier, msg, signature []byte, mode WrapMode) bool {
+	switch mode {
+	case WrapNone:
+		return verifier.Verify(msg, signature)
+	case WrapAlways:
+		return VerifyWrapped(verifier, msg, signature)
+	case WrapEither:
+		return verifier.Verify(msg, signature) ||
+			verifier.Verify(WrapBytes(msg), signature) ||
+			verifier.Verify(UnwrapBytes(msg), signature)
+	}
+
+	return false
+}
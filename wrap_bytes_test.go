@@ -0,0 +1,65 @@
+package subkey_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+)
+
+func TestWrapBytes(t *testing.T) {
+	wrapped := []byte("<Bytes>hello</Bytes>")
+	assert.Equal(t, wrapped, subkey.WrapBytes([]byte("hello")))
+	assert.Equal(t, wrapped, subkey.WrapBytes(wrapped))
+	assert.Equal(t, []byte("hello"), subkey.UnwrapBytes(wrapped))
+	assert.Equal(t, []byte("hello"), subkey.UnwrapBytes([]byte("hello")))
+	assert.Equal(t, []byte("<Bytes></Bytes>"), subkey.WrapBytes(nil))
+	assert.True(t, subkey.IsWrappedBytes([]byte("<Bytes></Bytes>")))
+	assert.False(t, subkey.IsWrappedBytes([]byte("<Bytes>")))
+	assert.False(t, subkey.IsWrappedBytes([]byte("<Bytes>hello")))
+}
+
+func TestSignWrapped(t *testing.T) {
+	msg := []byte("hello")
+	for _, scheme := range []subkey.Scheme{sr25519.Scheme{}, ed25519.Scheme{}, ecdsa.Scheme{}} {
+		kp, err := subkey.DeriveKeyPair(scheme, "//Alice")
+		assert.NoError(t, err)
+
+		// signRaw of polkadot.js signs the wrapped message
+		wrappedSig, err := subkey.SignWrapped(kp, msg)
+		assert.NoError(t, err)
+		assert.True(t, kp.Verify(subkey.WrapBytes(msg), wrappedSig))
+		rawSig, err := kp.Sign(msg)
+		assert.NoError(t, err)
+
+		tests := []struct {
+			mode      subkey.WrapMode
+			msg, sig  []byte
+			valid     bool
+			wrappedOk bool
+		}{
+			{mode: subkey.WrapNone, msg: msg, sig: rawSig, valid: true},
+			{mode: subkey.WrapNone, msg: msg, sig: wrappedSig},
+			{mode: subkey.WrapAlways, msg: msg, sig: wrappedSig, valid: true, wrappedOk: true},
+			{mode: subkey.WrapAlways, msg: msg, sig: rawSig},
+			// an already wrapped message is not wrapped twice
+			{mode: subkey.WrapAlways, msg: subkey.WrapBytes(msg), sig: wrappedSig, valid: true, wrappedOk: true},
+			{mode: subkey.WrapEither, msg: msg, sig: rawSig, valid: true},
+			{mode: subkey.WrapEither, msg: msg, sig: wrappedSig, valid: true, wrappedOk: true},
+			// a wrapped message whose raw form was signed
+			{mode: subkey.WrapEither, msg: subkey.WrapBytes(msg), sig: rawSig, valid: true},
+			{mode: subkey.WrapEither, msg: []byte("other"), sig: wrappedSig},
+			{mode: subkey.WrapMode(-1), msg: msg, sig: wrappedSig},
+		}
+
+		for _, c := range tests {
+			assert.Equal(t, c.valid, subkey.VerifyMode(kp, c.msg, c.sig, c.mode), "%v mode %d", scheme, c.mode)
+			if c.mode == subkey.WrapAlways {
+				assert.Equal(t, c.wrappedOk, subkey.VerifyWrapped(kp, c.msg, c.sig), "%v", scheme)
+			}
+		}
+	}
+}